@@ -0,0 +1,388 @@
+// Package bases resuelve problemas chicos por fuerza bruta: enumera todas las
+// soluciones básicas de la forma estándar (cada elección de m columnas entre las
+// variables de decisión, holguras y excesos), clasifica cada una y se queda con
+// la mejor factible. Sirve para mostrar "todas las bases" en clase y como
+// oráculo para validar los resultados del simplex.
+package bases
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"autosimplex/internal/simplex"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MaxBases es la cantidad máxima de combinaciones de columnas que se enumeran.
+const MaxBases = 5000
+
+// ErrTooLarge indica que el problema tiene más de MaxBases combinaciones.
+var ErrTooLarge = errors.New("el problema es demasiado grande para enumerar todas sus bases")
+
+const eps = 1e-9
+
+// Kind clasifica una solución básica.
+type Kind string
+
+const (
+	// Feasible: todas las variables básicas son positivas.
+	Feasible Kind = "feasible"
+	// Degenerate: factible, con alguna variable básica en 0.
+	Degenerate Kind = "degenerate"
+	// Infeasible: alguna variable básica es negativa.
+	Infeasible Kind = "infeasible"
+	// Singular: las columnas elegidas no forman una base.
+	Singular Kind = "singular"
+)
+
+// Basis es una elección de m columnas y la solución básica que determina.
+type Basis struct {
+	// Columns son los índices base 1 (en Table.Columns) de las columnas básicas.
+	Columns []int    `json:"columns"`
+	Labels  []string `json:"labels"`
+	Kind    Kind     `json:"kind"`
+	// Values tiene el valor de cada columna (0 en las no básicas); nil si la
+	// base es singular.
+	Values []float64 `json:"values,omitempty"`
+	// Solution son los valores de las variables de decisión.
+	Solution  []float64 `json:"solution,omitempty"`
+	Objective float64   `json:"objective"`
+}
+
+// Table es el resultado de la enumeración.
+type Table struct {
+	// Columns describe las columnas de la forma estándar. Una fila de rango
+	// lo <= a·x <= hi se escribe como dos filas, con una holgura y un exceso, y
+	// las igualdades redundantes se descartan.
+	Columns []simplex.Variable `json:"columns"`
+	Bases   []Basis            `json:"bases"`
+	Counts  map[Kind]int       `json:"counts"`
+	// Best es la mejor base factible; nil si no hay ninguna.
+	Best *Basis `json:"best,omitempty"`
+	// Status es optimal, infeasible (ninguna base factible) o unbounded (alguna
+	// base factible tiene una columna que mejora el objetivo sin límite).
+	Status simplex.Status `json:"status"`
+}
+
+// Enumerate arma la forma estándar de p y recorre todas sus bases. Devuelve
+// ErrTooLarge si hay más de MaxBases combinaciones.
+func Enumerate(p simplex.Problem) (Table, error) {
+	n := p.Objective.Len()
+	_, cols := p.Constraints.Dims()
+	if cols != n+1 {
+		return Table{}, fmt.Errorf("la matriz de restricciones tiene %d columnas y se esperaban %d", cols, n+1)
+	}
+	a, b, columns := StandardForm(p)
+	keep, consistent := Independent(a, b)
+	if !consistent {
+		return Table{Columns: columns, Counts: map[Kind]int{}, Status: simplex.StatusInfeasible}, nil
+	}
+	a, b = pick(a, keep), pick(b, keep)
+	m, total := len(b), len(columns)
+	if count := binomial(total, m); count > MaxBases {
+		return Table{}, fmt.Errorf("%w: %d combinaciones (máximo %d)", ErrTooLarge, count, MaxBases)
+	}
+
+	c := make([]float64, total)
+	for j := range n {
+		c[j] = p.Objective.AtVec(j)
+	}
+	sense := 1.0
+	if p.Minimize {
+		sense = -1
+	}
+
+	t := Table{Columns: columns, Counts: map[Kind]int{}, Status: simplex.StatusInfeasible}
+	unbounded := false
+	combinations(total, m, func(idx []int) {
+		basis, ray := solveBasis(a, b, c, sense, idx)
+		for k, j := range idx {
+			basis.Columns[k] = j + 1
+			basis.Labels[k] = columns[j].Name
+		}
+		if basis.Values != nil {
+			basis.Solution = basis.Values[:n:n]
+		}
+		t.Bases = append(t.Bases, basis)
+		t.Counts[basis.Kind]++
+		unbounded = unbounded || ray
+	})
+
+	for i, basis := range t.Bases {
+		if basis.Kind != Feasible && basis.Kind != Degenerate {
+			continue
+		}
+		if t.Best == nil || sense*(basis.Objective-t.Best.Objective) > eps {
+			t.Best = &t.Bases[i]
+		}
+	}
+	switch {
+	case unbounded:
+		t.Status = simplex.StatusUnbounded
+	case t.Best != nil:
+		t.Status = simplex.StatusOptimal
+	}
+	return t, nil
+}
+
+// StandardForm escribe p como A·x = b con x >= 0: cada fila <= suma una
+// holgura, cada >= resta un exceso, las de igualdad quedan como están y las de
+// rango se parten en dos. No hay artificiales porque no se busca una base
+// inicial, y el lado derecho conserva su signo.
+func StandardForm(p simplex.Problem) (a [][]float64, b []float64, columns []simplex.Variable) {
+	n := p.Objective.Len()
+	rows, _ := p.Constraints.Dims()
+	used := make(map[string]bool)
+	for j := range n {
+		columns = append(columns, simplex.Variable{Index: j + 1, Kind: simplex.Decision, Row: -1, Name: name(p.VarNames, j, fmt.Sprintf("x%d", j+1))})
+		used[columns[j].Name] = true
+	}
+
+	type row struct {
+		src  int
+		rhs  float64
+		kind simplex.VariableKind
+	}
+	var std []row
+	for i := range rows {
+		rhs := p.Constraints.At(i, n)
+		switch sign(p.Signs, i) {
+		case "range":
+			lo := 0.0
+			if i < len(p.Lower) {
+				lo = p.Lower[i]
+			}
+			std = append(std, row{i, rhs, simplex.Slack}, row{i, lo, simplex.Surplus})
+		case ">=":
+			std = append(std, row{i, rhs, simplex.Surplus})
+		case "=":
+			std = append(std, row{i, rhs, ""})
+		default:
+			std = append(std, row{i, rhs, simplex.Slack})
+		}
+	}
+
+	a = make([][]float64, len(std))
+	b = make([]float64, len(std))
+	for k, r := range std {
+		b[k] = r.rhs
+		for j := range n {
+			a[k] = append(a[k], p.Constraints.At(r.src, j))
+		}
+	}
+	for k, r := range std {
+		if r.kind == "" {
+			continue
+		}
+		prefix, coef := "s", 1.0
+		if r.kind == simplex.Surplus {
+			prefix, coef = "e", -1
+		}
+		aux := simplex.AuxName(used, p.RowNames, r.src, prefix)
+		used[aux] = true
+		columns = append(columns, simplex.Variable{Index: len(columns) + 1, Kind: r.kind, Row: r.src, Name: aux})
+		for i := range a {
+			v := 0.0
+			if i == k {
+				v = coef
+			}
+			a[i] = append(a[i], v)
+		}
+	}
+	return a, b, columns
+}
+
+// Independent elige las filas de [A | b] que no son combinación lineal de las
+// anteriores: solo las igualdades pueden ser redundantes, porque cada
+// desigualdad tiene su propia holgura o exceso. consistent es false si una fila
+// redundante en A contradice a las anteriores en b.
+func Independent(a [][]float64, b []float64) (keep []int, consistent bool) {
+	var reduced [][]float64
+	var pivots []int
+	for i := range a {
+		v := append(append([]float64(nil), a[i]...), b[i])
+		for k, w := range reduced {
+			f := v[pivots[k]] / w[pivots[k]]
+			for j := range v {
+				v[j] -= f * w[j]
+			}
+		}
+		p := 0
+		for j := range a[i] {
+			if math.Abs(v[j]) > math.Abs(v[p]) {
+				p = j
+			}
+		}
+		if len(a[i]) == 0 || math.Abs(v[p]) < eps {
+			if math.Abs(v[len(v)-1]) > eps {
+				return nil, false
+			}
+			continue
+		}
+		reduced = append(reduced, v)
+		pivots = append(pivots, p)
+		keep = append(keep, i)
+	}
+	return keep, true
+}
+
+// pick devuelve los elementos de s en las posiciones idx.
+func pick[T any](s []T, idx []int) []T {
+	out := make([]T, len(idx))
+	for k, i := range idx {
+		out[k] = s[i]
+	}
+	return out
+}
+
+// solveBasis resuelve B·x_B = b para las columnas idx y clasifica la solución.
+// ray es true si la base es factible y alguna columna no básica mejora el
+// objetivo sin que ninguna básica disminuya (dirección no acotada).
+func solveBasis(a [][]float64, b, c []float64, sense float64, idx []int) (basis Basis, ray bool) {
+	m, total := len(b), len(c)
+	basis = Basis{Columns: make([]int, m), Labels: make([]string, m)}
+
+	values := make([]float64, total)
+	var lu mat.LU
+	if m > 0 {
+		B := mat.NewDense(m, m, nil)
+		for i := range m {
+			for k, j := range idx {
+				B.Set(i, k, a[i][j])
+			}
+		}
+		lu.Factorize(B)
+		if lu.Det() == 0 || lu.Cond() > 1e12 {
+			basis.Kind = Singular
+			return basis, false
+		}
+		var xB mat.VecDense
+		if err := lu.SolveVecTo(&xB, false, mat.NewVecDense(m, append([]float64(nil), b...))); err != nil {
+			basis.Kind = Singular
+			return basis, false
+		}
+		for k, j := range idx {
+			values[j] = clean(xB.AtVec(k))
+		}
+	}
+
+	basis.Kind = Feasible
+	for _, j := range idx {
+		switch {
+		case values[j] < -eps:
+			basis.Kind = Infeasible
+		case values[j] == 0 && basis.Kind == Feasible:
+			basis.Kind = Degenerate
+		}
+	}
+	for j, v := range values {
+		basis.Objective += c[j] * v
+	}
+	basis.Objective = clean(basis.Objective)
+	basis.Values = values
+	if basis.Kind == Infeasible {
+		return basis, false
+	}
+
+	// Costo reducido y dirección de cada columna no básica
+	inBasis := make([]bool, total)
+	for _, j := range idx {
+		inBasis[j] = true
+	}
+	for j := range total {
+		if inBasis[j] {
+			continue
+		}
+		d := make([]float64, m)
+		if m > 0 {
+			col := make([]float64, m)
+			for i := range m {
+				col[i] = a[i][j]
+			}
+			var dv mat.VecDense
+			if err := lu.SolveVecTo(&dv, false, mat.NewVecDense(m, col)); err != nil {
+				continue
+			}
+			for i := range m {
+				d[i] = dv.AtVec(i)
+			}
+		}
+		reduced := c[j]
+		bounded := false
+		for k, i := range idx {
+			reduced -= c[i] * d[k]
+			bounded = bounded || d[k] > eps
+		}
+		if sense*reduced > eps && !bounded {
+			return basis, true
+		}
+	}
+	return basis, false
+}
+
+// combinations llama a f con cada subconjunto de k elementos de {0..n-1}, en
+// orden lexicográfico. f no debe conservar el slice.
+func combinations(n, k int, f func([]int)) {
+	if k > n {
+		return
+	}
+	idx := make([]int, k)
+	for i := range idx {
+		idx[i] = i
+	}
+	for {
+		f(idx)
+		i := k - 1
+		for i >= 0 && idx[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		idx[i]++
+		for j := i + 1; j < k; j++ {
+			idx[j] = idx[j-1] + 1
+		}
+	}
+}
+
+// binomial cuenta las combinaciones de k entre n, saturando en MaxBases+1.
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	r := 1
+	for i := 1; i <= k; i++ {
+		r = r * (n - k + i) / i
+		if r > MaxBases {
+			return MaxBases + 1
+		}
+	}
+	return r
+}
+
+// clean lleva a 0 los residuos de redondeo.
+func clean(v float64) float64 {
+	if math.Abs(v) < eps {
+		return 0
+	}
+	return v
+}
+
+// sign devuelve el signo de la fila i, "<=" si no fue informado.
+func sign(signs []string, i int) string {
+	if i < len(signs) {
+		return signs[i]
+	}
+	return "<="
+}
+
+// name devuelve names[i] si fue informado y no está vacío, o def en otro caso.
+func name(names []string, i int, def string) string {
+	if i < len(names) && names[i] != "" {
+		return names[i]
+	}
+	return def
+}
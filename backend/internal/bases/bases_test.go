@@ -0,0 +1,135 @@
+package bases
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func problem(c []float64, rows [][]float64, signs ...string) simplex.Problem {
+	m := mat.NewDense(len(rows), len(c)+1, nil)
+	for i, r := range rows {
+		m.SetRow(i, r)
+	}
+	return simplex.Problem{Objective: mat.NewVecDense(len(c), c), Constraints: m, Signs: signs}
+}
+
+func TestEnumerate(t *testing.T) {
+	// max 3 x1 + 2 x2 s.a. x1 + x2 <= 4, x1 + 3 x2 <= 6
+	tbl, err := Enumerate(problem([]float64{3, 2}, [][]float64{{1, 1, 4}, {1, 3, 6}}))
+	assert.NoError(t, err)
+
+	names := make([]string, len(tbl.Columns))
+	for i, v := range tbl.Columns {
+		names[i] = v.Name
+	}
+	assert.Equal(t, []string{"x1", "x2", "s1", "s2"}, names)
+	assert.Len(t, tbl.Bases, 6)
+	assert.Equal(t, map[Kind]int{Feasible: 4, Infeasible: 2}, tbl.Counts)
+
+	// La primera base es {x1, x2}: el vértice (3, 1)
+	assert.Equal(t, []string{"x1", "x2"}, tbl.Bases[0].Labels)
+	assert.Equal(t, []float64{3, 1}, tbl.Bases[0].Solution)
+	assert.Equal(t, 11.0, tbl.Bases[0].Objective)
+	// {x1, s1} da x1 = 6 y s1 = -2
+	assert.Equal(t, []int{1, 3}, tbl.Bases[1].Columns)
+	assert.Equal(t, Infeasible, tbl.Bases[1].Kind)
+	assert.Equal(t, []float64{6, 0, -2, 0}, tbl.Bases[1].Values)
+
+	assert.Equal(t, simplex.StatusOptimal, tbl.Status)
+	if assert.NotNil(t, tbl.Best) {
+		assert.Equal(t, []string{"x1", "s2"}, tbl.Best.Labels)
+		assert.Equal(t, 12.0, tbl.Best.Objective)
+	}
+}
+
+func TestEnumerateCases(t *testing.T) {
+	// Degenerada: tres rectas pasan por (2, 2)
+	tbl, err := Enumerate(problem([]float64{1, 1}, [][]float64{{1, 0, 2}, {0, 1, 2}, {1, 1, 4}}))
+	assert.NoError(t, err)
+	assert.Positive(t, tbl.Counts[Degenerate])
+	assert.Equal(t, 4.0, tbl.Best.Objective)
+
+	// Columnas repetidas: {x1, x2} es singular
+	tbl, _ = Enumerate(problem([]float64{1, 1}, [][]float64{{1, 1, 2}, {2, 2, 5}}))
+	assert.Equal(t, Singular, tbl.Bases[0].Kind)
+	assert.Nil(t, tbl.Bases[0].Values)
+
+	// No acotado: max x1 + x2 s.a. x1 - x2 <= 1
+	tbl, _ = Enumerate(problem([]float64{1, 1}, [][]float64{{1, -1, 1}}))
+	assert.Equal(t, simplex.StatusUnbounded, tbl.Status)
+
+	// Infactible: x1 + x2 <= 1 y x1 + x2 >= 2
+	tbl, _ = Enumerate(problem([]float64{1, 1}, [][]float64{{1, 1, 1}, {1, 1, 2}}, "<=", ">="))
+	assert.Equal(t, simplex.StatusInfeasible, tbl.Status)
+	assert.Nil(t, tbl.Best)
+
+	// Minimización con igualdad y rango: 1 <= x1 <= 3, x1 + x2 = 4
+	p := problem([]float64{2, 1}, [][]float64{{1, 0, 3}, {1, 1, 4}}, "range", "=")
+	p.Minimize, p.Lower, p.RowNames = true, []float64{1}, []string{"cap"}
+	tbl, _ = Enumerate(p)
+	assert.Equal(t, "s_cap", tbl.Columns[2].Name)
+	assert.Equal(t, "e_cap", tbl.Columns[3].Name)
+	assert.Equal(t, []float64{1, 3}, tbl.Best.Solution)
+	assert.Equal(t, 5.0, tbl.Best.Objective)
+
+	// Una variable de decisión llamada s_cap: la holgura se renombra
+	p.VarNames = []string{"s_cap", "y"}
+	tbl, _ = Enumerate(p)
+	assert.Equal(t, "s_cap_", tbl.Columns[2].Name)
+
+	// Demasiadas combinaciones
+	big := make([][]float64, 12)
+	for i := range big {
+		big[i] = make([]float64, 16)
+	}
+	_, err = Enumerate(problem(make([]float64, 15), big))
+	assert.True(t, errors.Is(err, ErrTooLarge))
+}
+
+// TestOracle compara SolveProblem con la enumeración en problemas chicos al azar.
+func TestOracle(t *testing.T) {
+	rng := rand.New(rand.NewSource(48))
+	signs := []string{"<=", "<=", ">=", "=", "range"}
+	for k := range 2000 {
+		n, m := 2+rng.Intn(2), 1+rng.Intn(3)
+		c := make([]float64, n)
+		for j := range c {
+			c[j] = float64(rng.Intn(9) - 3)
+		}
+		rows := make([][]float64, m)
+		sg := make([]string, m)
+		lower := make([]float64, m)
+		for i := range rows {
+			rows[i] = make([]float64, n+1)
+			for j := range n {
+				rows[i][j] = float64(rng.Intn(9) - 2)
+			}
+			rows[i][n] = float64(rng.Intn(15) - 4)
+			sg[i] = signs[rng.Intn(len(signs))]
+			lower[i] = rows[i][n] - float64(rng.Intn(6))
+		}
+		p := problem(c, rows, sg...)
+		p.Minimize, p.Lower = rng.Intn(2) == 0, lower
+
+		tbl, err := Enumerate(p)
+		assert.NoError(t, err)
+		res := simplex.SolveProblem(p)
+		if res.Status == simplex.StatusIterationLimit {
+			// Sin veredicto: el simplex cicló (no hay regla anticiclos)
+			continue
+		}
+		if !assert.Equal(t, tbl.Status, res.Status, "problema %d: %v %v %v min=%v", k, c, rows, sg, p.Minimize) {
+			continue
+		}
+		if res.Status == simplex.StatusOptimal {
+			assert.InDelta(t, tbl.Best.Objective, res.OptimalValue, 1e-6*math.Max(1, math.Abs(res.OptimalValue)), "problema %d", k)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Package codegen escribe una solicitud como un modelo equivalente para otras
+// herramientas: un programa Go que resuelve con gonum (optimize/convex/lp), un
+// script de Python con PuLP y un par .mod/.dat de AMPL. Sirve para pasar a otro
+// solver un modelo que ya no entra en autosimplex.
+package codegen
+
+import (
+	"archive/zip"
+	"embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"autosimplex/internal/models"
+)
+
+//go:embed templates/*.tmpl
+var files embed.FS
+
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+	"ampl":  amplString,
+	"join":  strings.Join,
+}).ParseFS(files, "templates/*.tmpl"))
+
+// row es una restricción con los números ya formateados.
+type row struct {
+	Name  string
+	Coefs []string
+	// Sign es "<=", ">=", "=" o "range"
+	Sign  string
+	RHS   string
+	Lower string
+}
+
+type model struct {
+	Minimize bool
+	Names    []string
+	Costs    []string
+	Rows     []row
+	Integer  []bool
+	Integers []string
+}
+
+func newModel(req models.SimplexRequest) (model, error) {
+	n := req.Objective.N
+	cols := req.Constraints.Cols
+	if n <= 0 || cols != n+1 || len(req.Objective.Coefficients) != n || len(req.Constraints.Vars) != req.Constraints.Rows*cols {
+		return model{}, fmt.Errorf("la solicitud no tiene dimensiones consistentes")
+	}
+	m := model{
+		Minimize: strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize"),
+		Costs:    numbers(req.Objective.Coefficients),
+		Integer:  make([]bool, n),
+	}
+	used := map[string]bool{}
+	for j := range n {
+		m.Names = append(m.Names, uniqueName(used, req.Objective.Names, j, "x"))
+	}
+	for _, j := range req.Integers {
+		if j >= 0 && j < n {
+			m.Integer[j] = true
+		}
+	}
+	for j, ok := range m.Integer {
+		if ok {
+			m.Integers = append(m.Integers, m.Names[j])
+		}
+	}
+
+	used = map[string]bool{}
+	for i := range req.Constraints.Rows {
+		vals := req.Constraints.Vars[i*cols : (i+1)*cols]
+		r := row{
+			Name:  uniqueName(used, req.Constraints.Names, i, "c"),
+			Coefs: numbers(vals[:n]),
+			Sign:  "<=",
+			RHS:   number(vals[n]),
+			Lower: "0",
+		}
+		if i < len(req.Constraints.Signs) && req.Constraints.Signs[i] != "" {
+			r.Sign = req.Constraints.Signs[i]
+		}
+		if i < len(req.Constraints.Lower) {
+			r.Lower = number(req.Constraints.Lower[i])
+		}
+		m.Rows = append(m.Rows, r)
+	}
+	return m, nil
+}
+
+// WriteGo escribe un programa Go que arma el problema en la forma general de
+// gonum (G·x ≤ h, A·x = b), lo pasa a forma estándar con lp.Convert y lo resuelve
+// con lp.Simplex.
+func WriteGo(w io.Writer, req models.SimplexRequest) error {
+	m, err := newModel(req)
+	if err != nil {
+		return err
+	}
+	return templates.ExecuteTemplate(w, "gonum.go.tmpl", m.general())
+}
+
+// WritePuLP escribe un script de Python que arma y resuelve el modelo con PuLP.
+func WritePuLP(w io.Writer, req models.SimplexRequest) error {
+	m, err := newModel(req)
+	if err != nil {
+		return err
+	}
+	return templates.ExecuteTemplate(w, "pulp.py.tmpl", m)
+}
+
+// WriteAMPL escribe un zip con modelo.mod (el modelo genérico con conjuntos y
+// parámetros), modelo.dat (los datos) y modelo.run (los comandos para resolverlo).
+func WriteAMPL(w io.Writer, req models.SimplexRequest) error {
+	m, err := newModel(req)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	for _, f := range []struct{ name, tmpl string }{
+		{"modelo.mod", "ampl.mod.tmpl"},
+		{"modelo.dat", "ampl.dat.tmpl"},
+		{"modelo.run", "ampl.run.tmpl"},
+	} {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if err := templates.ExecuteTemplate(fw, f.tmpl, m); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// RowsWith devuelve las filas con el signo dado, para los conjuntos de AMPL.
+func (m model) RowsWith(sign string) []row {
+	var out []row
+	for _, r := range m.Rows {
+		if r.Sign == sign {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Expression escribe la suma de PuLP pulp.lpSum([3 * x["a"], -1 * x["b"]]),
+// omitiendo coeficientes nulos.
+func (m model) Expression(coefs []string) string {
+	var terms []string
+	for j, c := range coefs {
+		if c == "0" {
+			continue
+		}
+		terms = append(terms, fmt.Sprintf("%s * x[%s]", c, strconv.Quote(m.Names[j])))
+	}
+	return "pulp.lpSum([" + strings.Join(terms, ", ") + "])"
+}
+
+// generalRow es una fila de G·x ≤ h o A·x = b para el programa Go.
+type generalRow struct {
+	Coefs   []string
+	RHS     string
+	Comment string
+}
+
+type general struct {
+	model
+	// C son los costos a minimizar
+	C []string
+	G []generalRow
+	A []generalRow
+}
+
+// general pasa el modelo a la forma de lp.Convert: las filas ≥ se multiplican
+// por −1, cada rango da dos filas y la no negatividad se agrega como −x ≤ 0,
+// porque Convert trata las variables como libres. Si se maximiza se cambia el
+// signo de los costos, ya que lp.Simplex minimiza.
+func (m model) general() general {
+	g := general{model: m, C: m.Costs}
+	if !m.Minimize {
+		g.C = negate(m.Costs)
+	}
+	for _, r := range m.Rows {
+		switch r.Sign {
+		case ">=":
+			g.G = append(g.G, generalRow{negate(r.Coefs), negateNumber(r.RHS), r.Name + " (≥, con signo cambiado)"})
+		case "=":
+			g.A = append(g.A, generalRow{r.Coefs, r.RHS, r.Name})
+		case "range":
+			g.G = append(g.G,
+				generalRow{r.Coefs, r.RHS, r.Name + " (cota superior)"},
+				generalRow{negate(r.Coefs), negateNumber(r.Lower), r.Name + " (cota inferior)"})
+		default:
+			g.G = append(g.G, generalRow{r.Coefs, r.RHS, r.Name})
+		}
+	}
+	for j, name := range m.Names {
+		coefs := make([]string, len(m.Names))
+		for k := range coefs {
+			coefs[k] = "0"
+		}
+		coefs[j] = "-1"
+		g.G = append(g.G, generalRow{coefs, "0", name + " ≥ 0"})
+	}
+	return g
+}
+
+// uniqueName usa el nombre de la solicitud si existe o prefix + número, y le
+// agrega "_" hasta que no choque con otro ya usado.
+func uniqueName(used map[string]bool, names []string, i int, prefix string) string {
+	name := fmt.Sprintf("%s%d", prefix, i+1)
+	if i < len(names) && names[i] != "" {
+		name = names[i]
+	}
+	for used[name] {
+		name += "_"
+	}
+	used[name] = true
+	return name
+}
+
+// amplString escribe s como cadena de AMPL, entre comillas simples.
+func amplString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func negate(v []string) []string {
+	out := make([]string, len(v))
+	for i, s := range v {
+		out[i] = negateNumber(s)
+	}
+	return out
+}
+
+func negateNumber(s string) string {
+	switch {
+	case s == "0":
+		return s
+	case strings.HasPrefix(s, "-"):
+		return s[1:]
+	}
+	return "-" + s
+}
+
+func numbers(v []float64) []string {
+	out := make([]string, len(v))
+	for i, x := range v {
+		out[i] = number(x)
+	}
+	return out
+}
+
+func number(v float64) string {
+	if v == 0 {
+		// Evita "-0"
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,93 @@
+package codegen
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"autosimplex/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sample: max 3·mesas + 2·sillas con una fila de cada tipo.
+func sample() models.SimplexRequest {
+	return models.SimplexRequest{
+		Objective: models.Objective{N: 2, Coefficients: []float64{3, 2}, Names: []string{"mesas", "sillas"}},
+		Constraints: models.Constraints{
+			Rows:  4,
+			Cols:  3,
+			Vars:  []float64{1, 1, 4, 1, 3, 6, 1, 0, 1, 0, 1, 3},
+			Signs: []string{"<=", ">=", "=", "range"},
+			Lower: []float64{0, 0, 0, 1},
+			Names: []string{"madera", "horas", "", "o'brien"},
+		},
+		Integers: []int{1},
+	}
+}
+
+func TestWriteGo(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteGo(&buf, sample()))
+	out := buf.String()
+
+	assert.Contains(t, out, `names := []string{"mesas", "sillas"}`)
+	assert.Contains(t, out, "c := []float64{-3, -2}")
+	assert.Contains(t, out, "G := mat.NewDense(6, 2, []float64{")
+	assert.Contains(t, out, "-1, -3, // horas (≥, con signo cambiado)")
+	assert.Contains(t, out, "0, -1, // o'brien (cota inferior)")
+	assert.Contains(t, out, "h := []float64{4, -6, 3, -1, 0, 0}")
+	assert.Contains(t, out, "A := mat.NewDense(1, 2, []float64{\n\t\t1, 0, // c3")
+	assert.Contains(t, out, "lp.Convert(c, G, h, A, b)")
+	assert.Contains(t, out, `fmt.Println("Z =", -opt)`)
+	assert.Contains(t, out, "sillas) no se imponen")
+}
+
+func TestWritePuLP(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WritePuLP(&buf, sample()))
+	out := buf.String()
+
+	assert.Contains(t, out, "pulp.LpProblem(\"autosimplex\", pulp.LpMaximize)")
+	assert.Contains(t, out, `"sillas": pulp.LpVariable("sillas", lowBound=0, cat="Integer"),`)
+	assert.Contains(t, out, `prob += pulp.lpSum([3 * x["mesas"], 2 * x["sillas"]]), "Z"`)
+	assert.Contains(t, out, `prob += pulp.lpSum([1 * x["mesas"], 3 * x["sillas"]]) >= 6, "horas"`)
+	assert.Contains(t, out, `prob += pulp.lpSum([1 * x["mesas"]]) == 1, "c3"`)
+	assert.Contains(t, out, `prob += pulp.lpSum([1 * x["sillas"]]) >= 1, "o'brien_inf"`)
+}
+
+func TestWriteAMPL(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteAMPL(&buf, sample()))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	assert.Len(t, files, 3)
+	assert.Contains(t, files["modelo.mod"], "maximize Z: sum {j in VARS} c[j] * x[j];")
+	assert.Contains(t, files["modelo.run"], "data modelo.dat;")
+
+	dat := files["modelo.dat"]
+	assert.Contains(t, dat, "set VARS := 'mesas' 'sillas';")
+	assert.Contains(t, dat, "set ROWS := 'madera' 'horas' 'c3' 'o''brien';")
+	assert.Contains(t, dat, "set GE := 'horas';")
+	assert.Contains(t, dat, "set INT := 'sillas';")
+	assert.Contains(t, dat, "param a : 'mesas' 'sillas' :=\n  'madera' 1 1\n")
+	assert.Contains(t, dat, "param lo := 'o''brien' 1;")
+}
+
+func TestWrite_Inconsistent(t *testing.T) {
+	req := sample()
+	req.Constraints.Cols = 4
+	assert.Error(t, WriteGo(io.Discard, req))
+	assert.Error(t, WritePuLP(io.Discard, req))
+	assert.Error(t, WriteAMPL(io.Discard, req))
+}
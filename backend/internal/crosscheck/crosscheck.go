@@ -0,0 +1,184 @@
+// Package crosscheck resuelve el problema por segunda vez con el simplex de
+// gonum (gonum.org/v1/gonum/optimize/convex/lp) y compara el veredicto y el
+// valor óptimo con los del solver propio.
+package crosscheck
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"autosimplex/internal/bases"
+	"autosimplex/internal/simplex"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// Solver identifica al segundo solver en el informe.
+const Solver = "gonum/lp"
+
+// Tolerance es la diferencia relativa admitida entre los dos valores óptimos.
+const Tolerance = 1e-6
+
+// Report es el resultado de la comparación.
+type Report struct {
+	Solver string `json:"solver"`
+	// Status es el veredicto de gonum; vacío si gonum no llegó a uno (ver Error).
+	Status       simplex.Status `json:"status,omitempty"`
+	OptimalValue *float64       `json:"optimal_value,omitempty"`
+	Solution     []float64      `json:"solution,omitempty"`
+	// Error es el error de gonum cuando no es un veredicto (falla numérica).
+	Error string `json:"error,omitempty"`
+	// Agree indica que los dos solvers coinciden en el estado y, si es óptimo,
+	// en el valor dentro de Tolerance.
+	Agree bool `json:"agree"`
+	// Difference es |Z propio - Z gonum| cuando los dos encontraron un óptimo.
+	Difference float64 `json:"difference,omitempty"`
+	// Message explica el desacuerdo; vacío si coinciden.
+	Message string `json:"message,omitempty"`
+}
+
+// Check resuelve p con gonum y compara el resultado con res, que es lo que
+// devolvió simplex.SolveProblem para el mismo problema.
+func Check(p simplex.Problem, res simplex.Result) Report {
+	r := Solve(p)
+	switch {
+	case r.Status == "":
+		r.Message = "gonum no pudo resolver el problema: " + r.Error
+	case r.Status != res.Status:
+		r.Message = fmt.Sprintf("el estado difiere: %s según el simplex y %s según gonum", res.Status, r.Status)
+	case r.Status == simplex.StatusOptimal:
+		r.Difference = math.Abs(res.OptimalValue - *r.OptimalValue)
+		if r.Difference > Tolerance*math.Max(1, math.Abs(*r.OptimalValue)) {
+			r.Message = fmt.Sprintf("el valor óptimo difiere: %g según el simplex y %g según gonum", res.OptimalValue, *r.OptimalValue)
+		} else {
+			r.Agree = true
+		}
+	default:
+		r.Agree = true
+	}
+	return r
+}
+
+// Solve pasa p a la forma estándar que pide lp.Simplex (minimizar c·x con
+// A·x = b, x >= 0, A de rango completo y sin filas ni columnas nulas) y lo
+// resuelve. Agree y Message quedan vacíos.
+func Solve(p simplex.Problem) Report {
+	r := Report{Solver: Solver}
+	n := p.Objective.Len()
+	if _, cols := p.Constraints.Dims(); cols != n+1 {
+		r.Error = fmt.Sprintf("la matriz de restricciones tiene %d columnas y se esperaban %d", cols, n+1)
+		return r
+	}
+
+	a, b, columns := bases.StandardForm(p)
+	keep, consistent := bases.Independent(a, b)
+	if !consistent {
+		r.Status = simplex.StatusInfeasible
+		return r
+	}
+	sense := -1.0 // lp.Simplex minimiza
+	if p.Minimize {
+		sense = 1
+	}
+
+	// Las columnas nulas no pueden ir a lp.Simplex: en el óptimo valen 0, salvo
+	// que mejoren el objetivo, y entonces el problema (si es factible) no está
+	// acotado
+	var used []int
+	improving := false
+	for j, v := range columns {
+		cost := 0.0
+		if v.Kind == simplex.Decision {
+			cost = sense * p.Objective.AtVec(j)
+		}
+		zero := true
+		for _, i := range keep {
+			zero = zero && a[i][j] == 0
+		}
+		if !zero {
+			used = append(used, j)
+		} else if cost < 0 {
+			improving = true
+		}
+	}
+
+	x := make([]float64, len(columns))
+	value := 0.0
+	if len(keep) > 0 {
+		A := mat.NewDense(len(keep), len(used), nil)
+		rhs := make([]float64, len(keep))
+		c := make([]float64, len(used))
+		for k, j := range used {
+			if columns[j].Kind == simplex.Decision {
+				c[k] = sense * p.Objective.AtVec(j)
+			}
+		}
+		for row, i := range keep {
+			rhs[row] = b[i]
+			for k, j := range used {
+				A.Set(row, k, a[i][j])
+			}
+		}
+		// Con tantas filas como columnas la única solución es la de A·x = b, y
+		// lp.Simplex a veces la da por infactible: se resuelve directamente
+		sol, err := square(A, rhs)
+		if sol == nil && err == nil {
+			_, sol, err = lp.Simplex(c, A, rhs, 1e-10, nil)
+		}
+		switch {
+		case errors.Is(err, lp.ErrInfeasible):
+			r.Status = simplex.StatusInfeasible
+			return r
+		case errors.Is(err, lp.ErrUnbounded):
+			r.Status = simplex.StatusUnbounded
+			return r
+		case err != nil:
+			r.Error = err.Error()
+			return r
+		}
+		opt := 0.0
+		for k, j := range used {
+			x[j] = sol[k]
+			opt += c[k] * sol[k]
+		}
+		value = sense * opt
+	}
+	if improving {
+		r.Status = simplex.StatusUnbounded
+		return r
+	}
+
+	r.Status = simplex.StatusOptimal
+	if value == 0 {
+		value = 0 // sin el -0 que deja el cambio de signo
+	}
+	r.OptimalValue = &value
+	r.Solution = x[:n:n]
+	return r
+}
+
+// square resuelve A·x = b cuando A es cuadrada e invertible: devuelve x si no
+// tiene componentes negativas y lp.ErrInfeasible si las tiene. Si A no es
+// cuadrada o es singular devuelve nil y nil, y la decisión queda en lp.Simplex.
+func square(A *mat.Dense, b []float64) ([]float64, error) {
+	m, n := A.Dims()
+	if m != n {
+		return nil, nil
+	}
+	var x mat.VecDense
+	if err := x.SolveVec(A, mat.NewVecDense(m, append([]float64(nil), b...))); err != nil {
+		return nil, nil
+	}
+	sol := make([]float64, n)
+	for k := range sol {
+		switch v := x.AtVec(k); {
+		case v < -1e-9:
+			return nil, lp.ErrInfeasible
+		case v > 0:
+			sol[k] = v
+		}
+	}
+	return sol, nil
+}
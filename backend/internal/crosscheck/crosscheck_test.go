@@ -0,0 +1,107 @@
+package crosscheck
+
+import (
+	"math/rand"
+	"testing"
+
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func problem(c []float64, rows [][]float64, signs ...string) simplex.Problem {
+	m := mat.NewDense(len(rows), len(c)+1, nil)
+	for i, r := range rows {
+		m.SetRow(i, r)
+	}
+	return simplex.Problem{Objective: mat.NewVecDense(len(c), c), Constraints: m, Signs: signs}
+}
+
+func TestCheck(t *testing.T) {
+	// max 3 x1 + 2 x2 s.a. x1 + x2 <= 4, x1 + 3 x2 <= 6
+	p := problem([]float64{3, 2}, [][]float64{{1, 1, 4}, {1, 3, 6}})
+	r := Check(p, simplex.SolveProblem(p))
+	assert.True(t, r.Agree, r.Message)
+	assert.Equal(t, Solver, r.Solver)
+	assert.Equal(t, simplex.StatusOptimal, r.Status)
+	assert.InDelta(t, 12, *r.OptimalValue, 1e-9)
+	assert.InDeltaSlice(t, []float64{4, 0}, r.Solution, 1e-9)
+
+	// Un resultado distinto se informa como desacuerdo
+	r = Check(p, simplex.Result{Status: simplex.StatusOptimal, OptimalValue: 11})
+	assert.False(t, r.Agree)
+	assert.InDelta(t, 1, r.Difference, 1e-9)
+	assert.Contains(t, r.Message, "valor óptimo")
+	r = Check(p, simplex.Result{Status: simplex.StatusUnbounded})
+	assert.False(t, r.Agree)
+	assert.Contains(t, r.Message, "estado")
+}
+
+func TestSolveCases(t *testing.T) {
+	// Infactible
+	r := Solve(problem([]float64{1, 1}, [][]float64{{1, 1, 1}, {1, 1, 2}}, "<=", ">="))
+	assert.Equal(t, simplex.StatusInfeasible, r.Status)
+	assert.Nil(t, r.OptimalValue)
+
+	// No acotado por una variable que no aparece en las restricciones
+	r = Solve(problem([]float64{1, 1}, [][]float64{{1, 0, 4}}))
+	assert.Equal(t, simplex.StatusUnbounded, r.Status)
+	// La misma variable con costo negativo queda en 0
+	r = Solve(problem([]float64{1, -1}, [][]float64{{1, 0, 4}}))
+	assert.Equal(t, 4.0, *r.OptimalValue)
+
+	// Igualdad redundante, lado derecho negativo y minimización
+	p := problem([]float64{2, 1}, [][]float64{{1, 1, 4}, {2, 2, 8}, {-1, 0, -1}}, "=", "=", "<=")
+	p.Minimize = true
+	r = Solve(p)
+	assert.Equal(t, simplex.StatusOptimal, r.Status)
+	assert.InDelta(t, 5, *r.OptimalValue, 1e-9)
+
+	// Sistema cuadrado: max x2 s.a. 5 x1 + 4 x2 = 7, x1 = 0. lp.Simplex lo da
+	// por infactible
+	p = problem([]float64{0, 1}, [][]float64{{5, 4, 7}, {1, 0, 0}}, "=", "=")
+	r = Check(p, simplex.SolveProblem(p))
+	assert.True(t, r.Agree, r.Message)
+	assert.Equal(t, simplex.StatusOptimal, r.Status)
+	assert.InDelta(t, 1.75, *r.OptimalValue, 1e-9)
+	assert.InDeltaSlice(t, []float64{0, 1.75}, r.Solution, 1e-9)
+
+	// Cuadrado con la única solución negativa: infactible
+	r = Solve(problem([]float64{0, 1}, [][]float64{{5, 4, -7}, {1, 0, 0}}, "=", "="))
+	assert.Equal(t, simplex.StatusInfeasible, r.Status)
+}
+
+// TestAgreement compara los dos solvers en problemas chicos al azar.
+func TestAgreement(t *testing.T) {
+	rng := rand.New(rand.NewSource(49))
+	signs := []string{"<=", "<=", ">=", "=", "range"}
+	for k := range 1000 {
+		n, m := 2+rng.Intn(3), 1+rng.Intn(4)
+		c := make([]float64, n)
+		for j := range c {
+			c[j] = float64(rng.Intn(9) - 3)
+		}
+		rows := make([][]float64, m)
+		sg := make([]string, m)
+		lower := make([]float64, m)
+		for i := range rows {
+			rows[i] = make([]float64, n+1)
+			for j := range n {
+				rows[i][j] = float64(rng.Intn(9) - 2)
+			}
+			rows[i][n] = float64(rng.Intn(15) - 4)
+			sg[i] = signs[rng.Intn(len(signs))]
+			lower[i] = rows[i][n] - float64(rng.Intn(6))
+		}
+		p := problem(c, rows, sg...)
+		p.Minimize, p.Lower = rng.Intn(2) == 0, lower
+
+		res := simplex.SolveProblem(p)
+		if res.Status == simplex.StatusIterationLimit {
+			continue
+		}
+		r := Check(p, res)
+		assert.True(t, r.Agree, "problema %d: %s", k, r.Message)
+	}
+}
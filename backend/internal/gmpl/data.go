@@ -0,0 +1,183 @@
+package gmpl
+
+import (
+	"strconv"
+	"strings"
+)
+
+// keyOf une los subíndices de un parámetro o variable en una clave de mapa.
+func keyOf(keys []string) string {
+	return strings.Join(keys, "\x1f")
+}
+
+// symbol escribe un número como elemento de conjunto: 1, 2.5, -3.
+func symbol(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// member lee un elemento de conjunto de la sección de datos: un nombre, una
+// cadena o un número (con signo).
+func member(s *stream) (string, error) {
+	t := s.next()
+	switch {
+	case t.kind == tIdent, t.kind == tString:
+		return t.text, nil
+	case t.kind == tNumber:
+		return symbol(t.num), nil
+	case t.is("-") && s.peek().kind == tNumber:
+		return symbol(-s.next().num), nil
+	}
+	return "", errorf(t, "se esperaba un elemento y se encontró %s", describe(t))
+}
+
+// number lee un valor numérico de la sección de datos, con signo opcional.
+func number(s *stream) (float64, error) {
+	t := s.next()
+	sign := 1.0
+	if t.is("-") || t.is("+") {
+		if t.is("-") {
+			sign = -1
+		}
+		t = s.next()
+	}
+	if t.kind != tNumber {
+		return 0, errorf(t, "se esperaba un número y se encontró %s", describe(t))
+	}
+	return sign * t.num, nil
+}
+
+// readSet lee set NAME := a b c;
+func (p *program) readSet(s *stream) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	if _, ok := p.decls[name.text].(*setDecl); !ok {
+		return errorf(name, "%s no es un conjunto declarado en el modelo", name.text)
+	}
+	if _, ok := p.setData[name.text]; ok {
+		return errorf(name, "el conjunto %s tiene datos repetidos", name.text)
+	}
+	s.accept(":=")
+	members := []string{}
+	seen := map[string]bool{}
+	for !s.accept(";") {
+		if s.accept(",") {
+			continue
+		}
+		t := s.peek()
+		m, err := member(s)
+		if err != nil {
+			return err
+		}
+		if seen[m] {
+			return errorf(t, "el elemento %s está repetido en %s", m, name.text)
+		}
+		seen[m] = true
+		members = append(members, m)
+	}
+	p.setData[name.text] = members
+	return nil
+}
+
+// readParam lee los valores de un parámetro, como lista
+//
+//	param c := a 1 b 2;          param d := a x 1  a y 2;
+//
+// o, si tiene dos subíndices, como tabla
+//
+//	param d : x y := a 1 2  b 3 . ;
+//
+// donde "." deja el valor por defecto.
+func (p *program) readParam(s *stream) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	d, ok := p.decls[name.text].(*paramDecl)
+	if !ok {
+		return errorf(name, "%s no es un parámetro declarado en el modelo", name.text)
+	}
+	if _, ok := p.paramData[name.text]; ok {
+		return errorf(name, "el parámetro %s tiene datos repetidos", name.text)
+	}
+	dim := 0
+	if d.dom != nil {
+		dim = len(d.dom.entries)
+	}
+	values := map[string]float64{}
+	p.paramData[name.text] = values
+
+	if s.accept("default") {
+		v, err := number(s)
+		if err != nil {
+			return err
+		}
+		p.paramDefault[name.text] = v
+	}
+	if s.accept(";") {
+		return nil
+	}
+
+	if t := s.peek(); t.is(":") {
+		s.next()
+		if dim != 2 {
+			return errorf(t, "el formato de tabla solo sirve para parámetros con dos subíndices")
+		}
+		var cols []string
+		for !s.accept(":=") {
+			c, err := member(s)
+			if err != nil {
+				return err
+			}
+			cols = append(cols, c)
+		}
+		for !s.accept(";") {
+			row, err := member(s)
+			if err != nil {
+				return err
+			}
+			for _, c := range cols {
+				if s.accept(".") {
+					continue
+				}
+				v, err := number(s)
+				if err != nil {
+					return err
+				}
+				values[keyOf([]string{row, c})] = v
+			}
+		}
+		return nil
+	}
+
+	if _, err := s.expect(":="); err != nil {
+		return err
+	}
+	for !s.accept(";") {
+		if s.accept(",") {
+			continue
+		}
+		keys := make([]string, dim)
+		for k := range keys {
+			if keys[k], err = member(s); err != nil {
+				return err
+			}
+		}
+		if s.accept(".") {
+			continue
+		}
+		v, err := number(s)
+		if err != nil {
+			return err
+		}
+		values[keyOf(keys)] = v
+		if dim == 0 {
+			if _, err := s.expect(";"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,540 @@
+package gmpl
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+)
+
+// Topes para no colgar el servidor con 1..1e9 o con productos de dominios
+// grandes: elementos de un rango y combinaciones recorridas en total.
+const (
+	maxRange      = 100000
+	maxIterations = 1000000
+)
+
+// linear es una expresión lineal c + Σ coef·var; order conserva el orden de
+// aparición de las variables.
+type linear struct {
+	c     float64
+	terms map[string]float64
+	order []string
+}
+
+func constant(v float64) linear { return linear{c: v} }
+
+func (l linear) isConst() bool {
+	for _, v := range l.terms {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// plus devuelve l + f·o.
+func (l linear) plus(o linear, f float64) linear {
+	out := linear{c: l.c + f*o.c, terms: map[string]float64{}}
+	add := func(v string, coef float64) {
+		if _, ok := out.terms[v]; !ok {
+			out.order = append(out.order, v)
+		}
+		out.terms[v] += coef
+	}
+	for _, v := range l.order {
+		add(v, l.terms[v])
+	}
+	for _, v := range o.order {
+		add(v, f*o.terms[v])
+	}
+	return out
+}
+
+func (l linear) scale(f float64) linear {
+	return constant(0).plus(l, f)
+}
+
+// list devuelve los términos con coeficiente no nulo.
+func (l linear) list() []models.Term {
+	var out []models.Term
+	for _, v := range l.order {
+		if c := l.terms[v]; c != 0 {
+			out = append(out, models.Term{Var: v, Coef: c})
+		}
+	}
+	return out
+}
+
+// env asocia los índices mudos con el elemento que toman en la iteración actual.
+type env map[string]string
+
+type evaluator struct {
+	p    *program
+	sets map[string][]string
+	// instances son las variables ya expandidas, x[a,b]
+	instances map[string]bool
+	// evaluating detecta parámetros definidos en función de sí mismos
+	evaluating map[string]bool
+	// iterations cuenta las combinaciones de índices recorridas
+	iterations int
+}
+
+// instance es el nombre de una variable o restricción expandida: x[a,b].
+func instance(name string, keys []string) string {
+	if len(keys) == 0 {
+		return name
+	}
+	return name + "[" + strings.Join(keys, ",") + "]"
+}
+
+// expand arma el modelo plano recorriendo los dominios de cada declaración.
+func (p *program) expand() (models.LinearModel, error) {
+	e := &evaluator{p: p, sets: map[string][]string{}, instances: map[string]bool{}, evaluating: map[string]bool{}}
+	var m models.LinearModel
+	if p.objective == nil {
+		return m, &parser.Error{Line: 1, Column: 1, Msg: "falta la función objetivo (maximize o minimize)"}
+	}
+
+	for _, d := range p.vars {
+		err := e.iterate(d.dom, env{}, func(en env, keys []string) error {
+			name := instance(d.name, keys)
+			e.instances[name] = true
+			m.Variables = append(m.Variables, name)
+			lower, upper := 0.0, math.Inf(1)
+			switch {
+			case d.binary:
+				upper = 1
+			case d.lower == nil:
+				return errorf(d.tok, "la variable %s no tiene cota inferior: declárela con >= 0, el solver solo admite variables no negativas", d.name)
+			}
+			var err error
+			if d.lower != nil {
+				if lower, err = e.num(d.lower, en); err != nil {
+					return err
+				}
+				if lower < 0 {
+					return errorf(d.tok, "la variable %s tiene cota inferior negativa (%s), no soportada", name, symbol(lower))
+				}
+			}
+			if d.upper != nil {
+				if upper, err = e.num(d.upper, en); err != nil {
+					return err
+				}
+			}
+			if lower > 0 || !math.IsInf(upper, 1) {
+				m.Bounds = append(m.Bounds, models.Bound{Var: name, Lower: lower, Upper: upper})
+			}
+			if d.integer || d.binary {
+				m.Integers = append(m.Integers, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return m, at(err, d.tok)
+		}
+	}
+
+	obj, err := e.lin(p.objective.body, env{})
+	if err != nil {
+		return m, at(err, p.objective.tok)
+	}
+	// El solver no admite una constante en el objetivo, igual que en los archivos LP
+	if obj.c != 0 {
+		return m, errorf(p.objective.tok, "la función objetivo no admite términos constantes")
+	}
+	m.Objective = obj.list()
+	m.Sense = "maximize"
+	if p.objective.minimize {
+		m.Sense = "minimize"
+	}
+
+	for _, d := range p.cons {
+		err := e.iterate(d.dom, env{}, func(en env, keys []string) error {
+			row, err := e.row(d, en)
+			if err != nil {
+				return err
+			}
+			row.Name = instance(d.name, keys)
+			m.Rows = append(m.Rows, row)
+			return nil
+		})
+		if err != nil {
+			return m, at(err, d.tok)
+		}
+	}
+	return m, nil
+}
+
+// row evalúa una restricción para un juego de índices.
+func (e *evaluator) row(d *conDecl, en env) (models.Row, error) {
+	parts := make([]linear, len(d.parts))
+	for i, x := range d.parts {
+		l, err := e.lin(x, en)
+		if err != nil {
+			return models.Row{}, err
+		}
+		parts[i] = l
+	}
+	if len(parts) == 2 {
+		l := parts[0].plus(parts[1], -1)
+		return models.Row{Terms: l.list(), Sign: d.cmps[0], RHS: -l.c}, nil
+	}
+	lo, mid, hi := parts[0], parts[1], parts[2]
+	if d.cmps[0] == ">=" {
+		lo, hi = hi, lo
+	}
+	if !lo.isConst() || !hi.isConst() {
+		return models.Row{}, fmt.Errorf("en una restricción doble los extremos deben ser constantes")
+	}
+	return models.Row{Terms: mid.list(), Sign: "range", Lower: lo.c - mid.c, RHS: hi.c - mid.c}, nil
+}
+
+// at agrega la posición de la declaración a los errores que no la tienen.
+func at(err error, t token) error {
+	var perr *parser.Error
+	if errors.As(err, &perr) {
+		return err
+	}
+	return errorf(t, "%s: %v", t.text, err)
+}
+
+// iterate llama a fn con cada combinación de elementos del dominio que cumple la
+// condición. Sin dominio, llama una vez sin índices.
+func (e *evaluator) iterate(dom *domain, en env, fn func(env, []string) error) error {
+	if dom == nil {
+		return fn(en, nil)
+	}
+	var walk func(k int, en env, keys []string) error
+	walk = func(k int, en env, keys []string) error {
+		if k == len(dom.entries) {
+			if e.iterations++; e.iterations > maxIterations {
+				return fmt.Errorf("el modelo es demasiado grande para expandirlo")
+			}
+			if dom.cond != nil {
+				ok, err := e.cond(dom.cond, en)
+				if err != nil || !ok {
+					return err
+				}
+			}
+			return fn(en, keys)
+		}
+		entry := dom.entries[k]
+		members, err := e.set(entry.set, en)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			next := en
+			if entry.dummy != "" {
+				next = maps.Clone(en)
+				next[entry.dummy] = m
+			}
+			if err := walk(k+1, next, append(keys[:k:k], m)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(0, en, nil)
+}
+
+// set devuelve los elementos de un conjunto en orden.
+func (e *evaluator) set(se setExpr, en env) ([]string, error) {
+	switch s := se.(type) {
+	case setRef:
+		if members, ok := e.sets[s.name]; ok {
+			return members, nil
+		}
+		d, ok := e.p.decls[s.name].(*setDecl)
+		if !ok {
+			return nil, errorf(s.tok, "%s no es un conjunto", s.name)
+		}
+		members, ok := e.p.setData[s.name]
+		if !ok {
+			if d.value == nil {
+				return nil, errorf(s.tok, "el conjunto %s no tiene datos", s.name)
+			}
+			var err error
+			if members, err = e.set(d.value, env{}); err != nil {
+				return nil, err
+			}
+		}
+		e.sets[s.name] = members
+		return members, nil
+	case setRange:
+		from, err := e.num(s.from, en)
+		if err != nil {
+			return nil, err
+		}
+		to, err := e.num(s.to, en)
+		if err != nil {
+			return nil, err
+		}
+		by := 1.0
+		if s.by != nil {
+			if by, err = e.num(s.by, en); err != nil {
+				return nil, err
+			}
+		}
+		if by == 0 {
+			return nil, fmt.Errorf("el paso de un rango no puede ser 0")
+		}
+		if (to-from)/by+1 > maxRange {
+			return nil, fmt.Errorf("el rango %s..%s tiene demasiados elementos", symbol(from), symbol(to))
+		}
+		var members []string
+		for k := 0; ; k++ {
+			v := from + float64(k)*by
+			if (by > 0 && v > to) || (by < 0 && v < to) {
+				break
+			}
+			members = append(members, symbol(v))
+		}
+		return members, nil
+	case setLit:
+		var members []string
+		seen := map[string]bool{}
+		for _, x := range s.items {
+			m, err := e.sym(x, en)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[m] {
+				seen[m] = true
+				members = append(members, m)
+			}
+		}
+		return members, nil
+	}
+	return nil, fmt.Errorf("conjunto inválido")
+}
+
+// sym evalúa un subíndice: una cadena, un índice mudo o un número.
+func (e *evaluator) sym(x expr, en env) (string, error) {
+	switch v := x.(type) {
+	case strLit:
+		return v.s, nil
+	case ref:
+		if m, ok := en[v.name]; ok && len(v.idx) == 0 {
+			return m, nil
+		}
+	}
+	n, err := e.num(x, en)
+	return symbol(n), err
+}
+
+func (e *evaluator) num(x expr, en env) (float64, error) {
+	l, err := e.lin(x, en)
+	if err != nil {
+		return 0, err
+	}
+	if !l.isConst() {
+		return 0, fmt.Errorf("se esperaba una expresión constante, sin variables")
+	}
+	return l.c, nil
+}
+
+func (e *evaluator) cond(x expr, en env) (bool, error) {
+	c, ok := x.(condExpr)
+	if !ok {
+		v, err := e.num(x, en)
+		return v != 0, err
+	}
+	switch c.op {
+	case "and", "or":
+		l, err := e.cond(c.l, en)
+		if err != nil {
+			return false, err
+		}
+		if (c.op == "and") != l {
+			return l, nil
+		}
+		return e.cond(c.r, en)
+	case "not":
+		v, err := e.cond(c.l, en)
+		return !v, err
+	}
+	a, err := e.sym(c.l, en)
+	if err != nil {
+		return false, err
+	}
+	b, err := e.sym(c.r, en)
+	if err != nil {
+		return false, err
+	}
+	fa, errA := strconv.ParseFloat(a, 64)
+	fb, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		// Elementos simbólicos: solo se comparan por igualdad
+		switch c.op {
+		case "=":
+			return a == b, nil
+		case "<>":
+			return a != b, nil
+		}
+		return false, errorf(c.tok, "no se puede comparar %q con %q usando %s", a, b, c.op)
+	}
+	switch c.op {
+	case "<":
+		return fa < fb, nil
+	case "<=":
+		return fa <= fb, nil
+	case "=":
+		return fa == fb, nil
+	case "<>":
+		return fa != fb, nil
+	case ">=":
+		return fa >= fb, nil
+	}
+	return fa > fb, nil
+}
+
+func (e *evaluator) lin(x expr, en env) (linear, error) {
+	switch v := x.(type) {
+	case numLit:
+		return constant(v.v), nil
+	case strLit:
+		return linear{}, fmt.Errorf("se esperaba un número y se encontró la cadena %q", v.s)
+	case negExpr:
+		l, err := e.lin(v.x, en)
+		return l.scale(-1), err
+	case sumExpr:
+		total := constant(0)
+		err := e.iterate(v.dom, en, func(inner env, _ []string) error {
+			l, err := e.lin(v.body, inner)
+			total = total.plus(l, 1)
+			return err
+		})
+		return total, err
+	case condExpr:
+		return linear{}, errorf(v.tok, "una condición no puede usarse como número")
+	case binExpr:
+		return e.binary(v, en)
+	case ref:
+		return e.ref(v, en)
+	}
+	return linear{}, fmt.Errorf("expresión inválida")
+}
+
+func (e *evaluator) binary(v binExpr, en env) (linear, error) {
+	l, err := e.lin(v.l, en)
+	if err != nil {
+		return l, err
+	}
+	r, err := e.lin(v.r, en)
+	if err != nil {
+		return r, err
+	}
+	switch v.op {
+	case "+":
+		return l.plus(r, 1), nil
+	case "-":
+		return l.plus(r, -1), nil
+	case "*":
+		if l.isConst() {
+			return r.scale(l.c), nil
+		}
+		if r.isConst() {
+			return l.scale(r.c), nil
+		}
+		return linear{}, errorf(v.tok, "producto de variables: el modelo no es lineal")
+	case "/":
+		if !r.isConst() {
+			return linear{}, errorf(v.tok, "división por una variable: el modelo no es lineal")
+		}
+		if r.c == 0 {
+			return linear{}, errorf(v.tok, "división por cero")
+		}
+		return l.scale(1 / r.c), nil
+	}
+	if !l.isConst() || !r.isConst() {
+		return linear{}, errorf(v.tok, "potencia de una variable: el modelo no es lineal")
+	}
+	return constant(math.Pow(l.c, r.c)), nil
+}
+
+// ref evalúa un índice mudo, un parámetro o una variable.
+func (e *evaluator) ref(v ref, en env) (linear, error) {
+	if m, ok := en[v.name]; ok && len(v.idx) == 0 {
+		n, err := strconv.ParseFloat(m, 64)
+		if err != nil {
+			return linear{}, errorf(v.tok, "el índice %s vale %q, que no es un número", v.name, m)
+		}
+		return constant(n), nil
+	}
+	keys := make([]string, len(v.idx))
+	for k, x := range v.idx {
+		var err error
+		if keys[k], err = e.sym(x, en); err != nil {
+			return linear{}, err
+		}
+	}
+	switch d := e.p.decls[v.name].(type) {
+	case *paramDecl:
+		if want := dims(d.dom); len(keys) != want {
+			return linear{}, errorf(v.tok, "%s lleva %d subíndices, no %d", v.name, want, len(keys))
+		}
+		n, err := e.param(d, keys, v.tok)
+		return constant(n), err
+	case *varDecl:
+		if want := dims(d.dom); len(keys) != want {
+			return linear{}, errorf(v.tok, "%s lleva %d subíndices, no %d", v.name, want, len(keys))
+		}
+		name := instance(v.name, keys)
+		if !e.instances[name] {
+			return linear{}, errorf(v.tok, "%s no pertenece al dominio de %s", name, v.name)
+		}
+		return linear{terms: map[string]float64{name: 1}, order: []string{name}}, nil
+	case *setDecl:
+		return linear{}, errorf(v.tok, "el conjunto %s no puede usarse como número", v.name)
+	}
+	if _, ok := e.p.decls[v.name]; ok {
+		return linear{}, errorf(v.tok, "%s no puede usarse en una expresión", v.name)
+	}
+	return linear{}, errorf(v.tok, "%s no está declarado", v.name)
+}
+
+// param busca el valor de p[keys]: la fórmula del modelo, los datos, el default
+// de los datos y por último el default del modelo.
+func (e *evaluator) param(d *paramDecl, keys []string, t token) (float64, error) {
+	name := instance(d.name, keys)
+	if d.value != nil {
+		if e.evaluating[name] {
+			return 0, errorf(t, "%s está definido en función de sí mismo", name)
+		}
+		e.evaluating[name] = true
+		defer delete(e.evaluating, name)
+		en := env{}
+		if d.dom != nil {
+			for k, entry := range d.dom.entries {
+				if entry.dummy != "" {
+					en[entry.dummy] = keys[k]
+				}
+			}
+		}
+		return e.num(d.value, en)
+	}
+	if v, ok := e.p.paramData[d.name][keyOf(keys)]; ok {
+		return v, nil
+	}
+	if v, ok := e.p.paramDefault[d.name]; ok {
+		return v, nil
+	}
+	if d.def != nil {
+		return e.num(d.def, env{})
+	}
+	return 0, errorf(t, "falta el valor de %s", name)
+}
+
+func dims(d *domain) int {
+	if d == nil {
+		return 0
+	}
+	return len(d.entries)
+}
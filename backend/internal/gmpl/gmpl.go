@@ -0,0 +1,47 @@
+// Package gmpl lee un subconjunto de GNU MathProg (GMPL), el lenguaje de
+// modelado de GLPK, y lo expande en un modelo plano. Admite:
+//
+//	set I;  set J := 1..n;                    conjuntos simples y rangos
+//	param c{I, J} >= 0, default 0;            parámetros indexados
+//	var x{i in I, j in J} >= 0, <= u[i];      variables indexadas (deben ser >= 0)
+//	minimize costo: sum{i in I, j in J} c[i,j] * x[i,j];
+//	s.t. oferta{i in I}: sum{j in J} x[i,j] <= a[i];
+//	data; set I := s1 s2; param c : d1 d2 := s1 2 3  s2 4 1; end;
+//
+// Los dominios admiten un filtro ({i in I, j in J: i <> j}). Las sentencias
+// solve, display, printf y check se ignoran. Cada variable x[a,b] y cada
+// restricción oferta[s1] queda con ese nombre en la solicitud.
+package gmpl
+
+import (
+	"io"
+
+	"autosimplex/internal/models"
+)
+
+// Read lee un modelo GMPL con su sección de datos y lo convierte en una solicitud
+// para el solver.
+func Read(r io.Reader) (models.SimplexRequest, error) {
+	m, err := ReadModel(r)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
+
+// ReadModel lee un modelo GMPL y devuelve el modelo expandido, sin aplanarlo.
+func ReadModel(r io.Reader) (models.LinearModel, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return models.LinearModel{}, err
+	}
+	toks, err := lex(string(data))
+	if err != nil {
+		return models.LinearModel{}, err
+	}
+	p, err := parse(toks)
+	if err != nil {
+		return models.LinearModel{}, err
+	}
+	return p.expand()
+}
@@ -0,0 +1,153 @@
+package gmpl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Problema de transporte de los ejemplos de GLPK (transp.mod), reducido.
+const transport = `
+/* Problema de transporte */
+set I;   # plantas
+set J;   # mercados
+
+param a{i in I};
+param b{j in J};
+param d{i in I, j in J};
+param f, default 90;
+param c{i in I, j in J} := f * d[i,j] / 1000;
+
+var x{i in I, j in J} >= 0;
+
+minimize cost: sum{i in I, j in J} c[i,j] * x[i,j];
+
+s.t. supply{i in I}: sum{j in J} x[i,j] <= a[i];
+s.t. demand{j in J}: sum{i in I} x[i,j] >= b[j];
+
+solve;
+display x;
+
+data;
+
+set I := seattle san-diego;
+set J := 'new york' chicago topeka;
+
+param a := seattle 350  'san-diego' 600;
+param b := 'new york' 325  chicago 300  topeka 275;
+
+param d :  'new york'  chicago  topeka :=
+  seattle      2.5       1.7      1.8
+  'san-diego'  2.5       1.8      1.4 ;
+
+end;
+`
+
+func TestReadTransport(t *testing.T) {
+	m, err := ReadModel(strings.NewReader(transport))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "minimize", m.Sense)
+	assert.Len(t, m.Variables, 6)
+	assert.Equal(t, "x[seattle,new york]", m.Variables[0])
+	// Los elementos sin comillas pueden llevar guiones
+	assert.Equal(t, "x[san-diego,topeka]", m.Variables[5])
+	assert.InDelta(t, 0.225, m.Objective[0].Coef, 1e-12)
+
+	assert.Len(t, m.Rows, 5)
+	assert.Equal(t, "supply[seattle]", m.Rows[0].Name)
+	assert.Equal(t, "<=", m.Rows[0].Sign)
+	assert.Equal(t, 350.0, m.Rows[0].RHS)
+	assert.Equal(t, []models.Term{
+		{Var: "x[seattle,chicago]", Coef: 1},
+		{Var: "x[san-diego,chicago]", Coef: 1},
+	}, m.Rows[3].Terms)
+	assert.Equal(t, "demand[chicago]", m.Rows[3].Name)
+	assert.Equal(t, ">=", m.Rows[3].Sign)
+
+	req, err := m.ToRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, 6, req.Objective.N)
+	assert.Equal(t, 5, req.Constraints.Rows)
+}
+
+func TestReadFeatures(t *testing.T) {
+	src := `
+param n := 3;
+set K := 1..n;
+param w{K} default 1;
+var y{i in K, j in K: i <> j} >= 0, <= w[i];
+var z binary;
+var q >= 2;
+maximize obj: sum{i in K, j in K: i < j} (i + j) * y[i,j] + 2 * z - q;
+cap: 1 <= sum{i in K, j in K: i <> j} y[i,j] + 1 <= 4;
+link{k in K: k >= 2}: y[1,k] - z = 0;
+data;
+param w := 2 5;
+`
+	m, err := ReadModel(strings.NewReader(src))
+	assert.NoError(t, err)
+
+	// 6 pares i <> j, más z y q
+	assert.Len(t, m.Variables, 8)
+	assert.Equal(t, []string{"z"}, m.Integers)
+	assert.Contains(t, m.Bounds, models.Bound{Var: "y[2,1]", Lower: 0, Upper: 5})
+	assert.Contains(t, m.Bounds, models.Bound{Var: "y[1,2]", Lower: 0, Upper: 1})
+	assert.Contains(t, m.Bounds, models.Bound{Var: "z", Lower: 0, Upper: 1})
+	assert.Equal(t, 2.0, m.Bounds[len(m.Bounds)-1].Lower)
+
+	assert.Equal(t, []models.Term{
+		{Var: "y[1,2]", Coef: 3}, {Var: "y[1,3]", Coef: 4}, {Var: "y[2,3]", Coef: 5},
+		{Var: "z", Coef: 2}, {Var: "q", Coef: -1},
+	}, m.Objective)
+
+	assert.Equal(t, "range", m.Rows[0].Sign)
+	assert.Equal(t, 0.0, m.Rows[0].Lower)
+	assert.Equal(t, 3.0, m.Rows[0].RHS)
+	assert.Equal(t, []string{"link[2]", "link[3]"}, []string{m.Rows[1].Name, m.Rows[2].Name})
+	assert.Equal(t, "=", m.Rows[1].Sign)
+}
+
+func TestReadDataSymbols(t *testing.T) {
+	src := "set S;\nparam p{S};\nvar x{S} >= 0;\nmaximize z: sum{s in S} p[s] * x[s];\n" +
+		"data;\nset S := inf 1e-1 -2 a.b;\nparam p := inf 1  0.1 2  -2 -3  a.b 4;\n"
+	m, err := ReadModel(strings.NewReader(src))
+	assert.NoError(t, err)
+	// inf es un elemento, no un número; 1e-1 se escribe 0.1
+	assert.Equal(t, []string{"x[inf]", "x[0.1]", "x[-2]", "x[a.b]"}, m.Variables)
+	assert.Equal(t, -3.0, m.Objective[2].Coef)
+}
+
+func TestReadErrors(t *testing.T) {
+	cases := []struct {
+		src       string
+		line, col int
+		msg       string
+	}{
+		{"var x;\nmaximize z: x;\ns.t. c: x <= 1;", 1, 5, "no tiene cota inferior"},
+		{"var x >= 0;\nmaximize z: x * x;\ns.t. c: x <= 1;", 2, 15, "no es lineal"},
+		{"set I;\nvar x{I} >= 0;\nmaximize z: sum{i in I} x[i];", 2, 7, "no tiene datos"},
+		{"param p{1..2};\nvar x >= 0;\nmaximize z: p[1] * x;\ndata;\nparam p := 2 3;", 3, 13, "falta el valor de p[1]"},
+		{"var x >= 0;\nmaximize z: x + y;", 2, 17, "y no está declarado"},
+		{"var x{1..2} >= 0;\nmaximize z: x[3];", 2, 13, "no pertenece al dominio"},
+		{"var x >= 0;\nmaximize z: x;\ns.t. c: x <= 1", 3, 15, "se esperaba"},
+		{"var x >= 0;\nvar x >= 0;", 2, 5, "ya fue declarado"},
+		{"var x{1..1e9} >= 0;\nmaximize z: x[1];", 1, 5, "demasiados elementos"},
+		{"var x >= 0;\ns.t. c: x <= 1;", 1, 1, "falta la función objetivo"},
+		{"var x >= 0;\nmaximize z: x + 7;\ns.t. c: x <= 1;", 2, 10, "no admite términos constantes"},
+	}
+	for _, tc := range cases {
+		_, err := ReadModel(strings.NewReader(tc.src))
+		var perr *parser.Error
+		if assert.True(t, errors.As(err, &perr), tc.src) {
+			assert.Equal(t, tc.line, perr.Line, tc.src)
+			assert.Equal(t, tc.col, perr.Column, tc.src)
+			assert.Contains(t, perr.Msg, tc.msg, tc.src)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package gmpl
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"autosimplex/internal/parser"
+)
+
+type tokenKind int
+
+const (
+	tNumber tokenKind = iota
+	tIdent
+	tString
+	tSym
+	tEOF
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	line int
+	col  int
+}
+
+// is indica si t es el símbolo o la palabra clave s.
+func (t token) is(s string) bool {
+	return (t.kind == tSym || t.kind == tIdent) && t.text == s
+}
+
+// Símbolos de más de un carácter; se prueban antes que los simples.
+var symbols = []string{":=", "..", "<=", ">=", "<>", "!=", "==", "&&", "||", "**"}
+
+const singles = ";:,{}[]()+-*/^<>=."
+
+// isDataRune indica si r puede formar parte de un elemento escrito sin comillas
+// en la sección de datos, como San-Diego o 2.5.
+func isDataRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_+-.", r)
+}
+
+// lex divide src en tokens. Los comentarios van de "#" al fin de línea o entre
+// /* y */. Las columnas se cuentan en runas. Después de "data;" los elementos
+// sin comillas pueden llevar "-", "+" y "." (San-Diego, -3, 1e-2).
+func lex(src string) ([]token, error) {
+	rs := []rune(strings.ReplaceAll(src, "\r\n", "\n"))
+	var toks []token
+	line, lineStart := 1, 0
+	i := 0
+	data := false
+	errAt := func(pos int, msg string) error {
+		return &parser.Error{Line: line, Column: pos - lineStart + 1, Msg: msg}
+	}
+	for i < len(rs) {
+		r := rs[i]
+		col := i - lineStart + 1
+		switch {
+		case r == '\n':
+			i++
+			line, lineStart = line+1, i
+		case unicode.IsSpace(r):
+			i++
+		case r == '#':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case data && isDataRune(r) && !(r == '.' && (i+1 >= len(rs) || !isDataRune(rs[i+1]))):
+			start := i
+			for i < len(rs) && isDataRune(rs[i]) {
+				i++
+			}
+			text := string(rs[start:i])
+			// ParseFloat también acepta inf, nan y hexadecimales: solo cuentan
+			// como números los que no tienen más letras que el exponente
+			plain := !strings.ContainsFunc(text, func(r rune) bool { return unicode.IsLetter(r) && r != 'e' && r != 'E' })
+			if v, err := strconv.ParseFloat(text, 64); err == nil && plain {
+				toks = append(toks, token{kind: tNumber, text: text, num: v, line: line, col: col})
+			} else {
+				toks = append(toks, token{kind: tIdent, text: text, line: line, col: col})
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			start := i
+			i += 2
+			for i < len(rs) && (rs[i] != '*' || i+1 >= len(rs) || rs[i+1] != '/') {
+				if rs[i] == '\n' {
+					line, lineStart = line+1, i+1
+				}
+				i++
+			}
+			if i >= len(rs) {
+				return nil, errAt(start, "comentario sin cerrar")
+			}
+			i += 2
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			start := i
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || (rs[i] == '.' && (i+1 >= len(rs) || rs[i+1] != '.'))) {
+				i++
+			}
+			if i < len(rs) && (rs[i] == 'e' || rs[i] == 'E') {
+				j := i + 1
+				if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+					j++
+				}
+				if j < len(rs) && unicode.IsDigit(rs[j]) {
+					for i = j; i < len(rs) && unicode.IsDigit(rs[i]); i++ {
+					}
+				}
+			}
+			text := string(rs[start:i])
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, errAt(start, "número inválido: "+text)
+			}
+			toks = append(toks, token{kind: tNumber, text: text, num: v, line: line, col: col})
+		case r == '\'' || r == '"':
+			// Las comillas se escriben duplicadas dentro de la cadena
+			start := i
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(rs) || rs[i] == '\n' {
+					return nil, errAt(start, "cadena sin cerrar")
+				}
+				if rs[i] == r {
+					if i+1 < len(rs) && rs[i+1] == r {
+						b.WriteRune(r)
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteRune(rs[i])
+				i++
+			}
+			toks = append(toks, token{kind: tString, text: b.String(), line: line, col: col})
+		case unicode.IsLetter(r) || r == '_':
+			if strings.HasPrefix(string(rs[i:min(i+4, len(rs))]), "s.t.") {
+				toks = append(toks, token{kind: tIdent, text: "s.t.", line: line, col: col})
+				i += 4
+				continue
+			}
+			start := i
+			for i < len(rs) && (unicode.IsLetter(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '_') {
+				i++
+			}
+			toks = append(toks, token{kind: tIdent, text: string(rs[start:i]), line: line, col: col})
+		default:
+			matched := false
+			for _, s := range symbols {
+				if strings.HasPrefix(string(rs[i:min(i+len(s), len(rs))]), s) {
+					toks = append(toks, token{kind: tSym, text: s, line: line, col: col})
+					i += len(s)
+					matched = true
+					break
+				}
+			}
+			if matched {
+				continue
+			}
+			if !strings.ContainsRune(singles, r) {
+				return nil, errAt(i, "carácter inesperado "+strconv.QuoteRune(r))
+			}
+			toks = append(toks, token{kind: tSym, text: string(r), line: line, col: col})
+			i++
+			if r == ';' && len(toks) >= 2 && toks[len(toks)-2].is("data") {
+				data = true
+			}
+		}
+	}
+	return append(toks, token{kind: tEOF, line: line, col: i - lineStart + 1}), nil
+}
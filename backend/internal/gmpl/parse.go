@@ -0,0 +1,742 @@
+package gmpl
+
+import (
+	"fmt"
+	"strconv"
+
+	"autosimplex/internal/parser"
+)
+
+// Expresiones del modelo. Se evalúan recién al expandir, cuando ya se leyó la
+// sección de datos.
+type expr interface{}
+
+type (
+	numLit struct{ v float64 }
+	strLit struct{ s string }
+	// ref es un parámetro, una variable o un índice mudo, con o sin subíndices
+	ref struct {
+		name string
+		idx  []expr
+		tok  token
+	}
+	binExpr struct {
+		op   string
+		l, r expr
+		tok  token
+	}
+	negExpr struct{ x expr }
+	sumExpr struct {
+		dom  *domain
+		body expr
+	}
+	// condExpr es una comparación o una operación lógica (and, or, not) de un filtro
+	condExpr struct {
+		op   string
+		l, r expr
+		tok  token
+	}
+)
+
+// Expresiones de conjunto: un nombre, un rango a..b [by c] o una lista {a, b}.
+type setExpr interface{}
+
+type (
+	setRef struct {
+		name string
+		tok  token
+	}
+	setRange struct{ from, to, by expr }
+	setLit   struct{ items []expr }
+)
+
+// domain es un dominio de indexación {i in I, j in J: condición}.
+type domain struct {
+	entries []domainEntry
+	cond    expr
+}
+
+type domainEntry struct {
+	// dummy puede estar vacío en las declaraciones: param c{I, J}
+	dummy string
+	set   setExpr
+}
+
+type setDecl struct {
+	name  string
+	tok   token
+	value setExpr
+}
+
+type paramDecl struct {
+	name  string
+	tok   token
+	dom   *domain
+	def   expr
+	value expr
+}
+
+type varDecl struct {
+	name         string
+	tok          token
+	dom          *domain
+	lower, upper expr
+	integer      bool
+	binary       bool
+}
+
+type objDecl struct {
+	minimize bool
+	name     string
+	tok      token
+	body     expr
+}
+
+// conDecl es una restricción a cmp b, o a cmp b cmp c con a y c constantes.
+type conDecl struct {
+	name  string
+	tok   token
+	dom   *domain
+	parts []expr
+	cmps  []string
+}
+
+// program es el modelo leído junto con su sección de datos.
+type program struct {
+	sets      []*setDecl
+	params    []*paramDecl
+	vars      []*varDecl
+	objective *objDecl
+	cons      []*conDecl
+	// decls asocia cada nombre con su declaración
+	decls map[string]any
+
+	setData   map[string][]string
+	paramData map[string]map[string]float64
+	// paramDefault guarda los default de la sección de datos
+	paramDefault map[string]float64
+}
+
+type stream struct {
+	toks []token
+	pos  int
+}
+
+func (s *stream) peek() token        { return s.toks[s.pos] }
+func (s *stream) peekAt(k int) token { return s.toks[min(s.pos+k, len(s.toks)-1)] }
+
+func (s *stream) next() token {
+	t := s.toks[s.pos]
+	if t.kind != tEOF {
+		s.pos++
+	}
+	return t
+}
+
+func (s *stream) accept(sym string) bool {
+	if s.peek().is(sym) {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+func (s *stream) expect(sym string) (token, error) {
+	t := s.next()
+	if !t.is(sym) {
+		return t, errorf(t, "se esperaba %q y se encontró %s", sym, describe(t))
+	}
+	return t, nil
+}
+
+func (s *stream) ident() (token, error) {
+	t := s.next()
+	if t.kind != tIdent {
+		return t, errorf(t, "se esperaba un nombre y se encontró %s", describe(t))
+	}
+	return t, nil
+}
+
+func errorf(t token, format string, args ...any) *parser.Error {
+	return &parser.Error{Line: t.line, Column: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+func describe(t token) string {
+	switch t.kind {
+	case tEOF:
+		return "el fin del archivo"
+	case tString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// Sentencias que no cambian el modelo y se saltean hasta el ";".
+var ignored = map[string]bool{"solve": true, "display": true, "printf": true, "check": true}
+
+// parse lee las sentencias del modelo y, si hay "data;", la sección de datos.
+func parse(toks []token) (*program, error) {
+	p := &program{
+		decls:        map[string]any{},
+		setData:      map[string][]string{},
+		paramData:    map[string]map[string]float64{},
+		paramDefault: map[string]float64{},
+	}
+	s := &stream{toks: toks}
+	for s.peek().kind != tEOF {
+		t := s.peek()
+		switch {
+		case t.is("end"):
+			s.next()
+			s.accept(";")
+			return p, nil
+		case t.is("data"):
+			s.next()
+			if _, err := s.expect(";"); err != nil {
+				return nil, err
+			}
+			return p, p.data(s)
+		case t.kind == tIdent && ignored[t.text]:
+			for !s.peek().is(";") && s.peek().kind != tEOF {
+				s.next()
+			}
+			if _, err := s.expect(";"); err != nil {
+				return nil, err
+			}
+		default:
+			if err := p.statement(s); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p, nil
+}
+
+func (p *program) declare(t token, d any) error {
+	if _, ok := p.decls[t.text]; ok {
+		return errorf(t, "%s ya fue declarado", t.text)
+	}
+	p.decls[t.text] = d
+	return nil
+}
+
+func (p *program) statement(s *stream) error {
+	t := s.next()
+	switch {
+	case t.is("set"):
+		return p.set(s)
+	case t.is("param"):
+		return p.param(s)
+	case t.is("var"):
+		return p.variable(s)
+	case t.is("maximize"), t.is("minimize"):
+		return p.objectiveDecl(s, t)
+	case t.is("s.t."), t.is("subject"), t.is("subj"):
+		if !t.is("s.t.") {
+			if _, err := s.expect("to"); err != nil {
+				return err
+			}
+		}
+		return p.constraint(s)
+	case t.kind == tIdent && (s.peek().is(":") || s.peek().is("{")):
+		// La palabra s.t. es opcional
+		s.pos--
+		return p.constraint(s)
+	}
+	return errorf(t, "sentencia no soportada: %s", describe(t))
+}
+
+// skipAlias saltea el alias opcional que sigue al nombre: var x 'cantidad' >= 0.
+func skipAlias(s *stream) {
+	if s.peek().kind == tString {
+		s.next()
+	}
+}
+
+func (p *program) set(s *stream) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	d := &setDecl{name: name.text, tok: name}
+	if err := p.declare(name, d); err != nil {
+		return err
+	}
+	skipAlias(s)
+	if s.peek().is("{") {
+		return errorf(s.peek(), "los conjuntos indexados no están soportados")
+	}
+	for !s.accept(";") {
+		s.accept(",")
+		t := s.next()
+		switch {
+		case t.is("dimen"):
+			n := s.next()
+			if n.kind != tNumber || n.num != 1 {
+				return errorf(n, "solo se admiten conjuntos de dimensión 1")
+			}
+		case t.is(":="), t.is("default"):
+			if d.value, err = parseSet(s); err != nil {
+				return err
+			}
+		case t.is("within"):
+			// Solo documenta el conjunto; no se verifica
+			if _, err := parseSet(s); err != nil {
+				return err
+			}
+		default:
+			return errorf(t, "atributo de conjunto no soportado: %s", describe(t))
+		}
+	}
+	p.sets = append(p.sets, d)
+	return nil
+}
+
+func (p *program) param(s *stream) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	d := &paramDecl{name: name.text, tok: name}
+	if err := p.declare(name, d); err != nil {
+		return err
+	}
+	skipAlias(s)
+	if s.peek().is("{") {
+		if d.dom, err = parseDomain(s); err != nil {
+			return err
+		}
+	}
+	for !s.accept(";") {
+		s.accept(",")
+		t := s.next()
+		switch {
+		case t.is("integer"), t.is("binary"):
+			// Restricciones sobre los datos: no se verifican
+		case t.is("symbolic"):
+			return errorf(t, "los parámetros simbólicos no están soportados")
+		case t.is("default"):
+			if d.def, err = parseExpr(s); err != nil {
+				return err
+			}
+		case t.is(":="):
+			if d.value, err = parseExpr(s); err != nil {
+				return err
+			}
+		case t.is("in"):
+			if _, err := parseSet(s); err != nil {
+				return err
+			}
+		case t.is("<"), t.is("<="), t.is("="), t.is("=="), t.is(">="), t.is(">"), t.is("<>"), t.is("!="):
+			if _, err := parseExpr(s); err != nil {
+				return err
+			}
+		default:
+			return errorf(t, "atributo de parámetro no soportado: %s", describe(t))
+		}
+	}
+	p.params = append(p.params, d)
+	return nil
+}
+
+func (p *program) variable(s *stream) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	d := &varDecl{name: name.text, tok: name}
+	if err := p.declare(name, d); err != nil {
+		return err
+	}
+	skipAlias(s)
+	if s.peek().is("{") {
+		if d.dom, err = parseDomain(s); err != nil {
+			return err
+		}
+	}
+	for !s.accept(";") {
+		s.accept(",")
+		t := s.next()
+		switch {
+		case t.is("integer"):
+			d.integer = true
+		case t.is("binary"):
+			d.binary = true
+		case t.is(">="):
+			if d.lower, err = parseExpr(s); err != nil {
+				return err
+			}
+		case t.is("<="):
+			if d.upper, err = parseExpr(s); err != nil {
+				return err
+			}
+		case t.is("="), t.is("=="):
+			if d.lower, err = parseExpr(s); err != nil {
+				return err
+			}
+			d.upper = d.lower
+		default:
+			return errorf(t, "atributo de variable no soportado: %s", describe(t))
+		}
+	}
+	p.vars = append(p.vars, d)
+	return nil
+}
+
+func (p *program) objectiveDecl(s *stream, kw token) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	if err := p.declare(name, nil); err != nil {
+		return err
+	}
+	skipAlias(s)
+	if s.peek().is("{") {
+		return errorf(s.peek(), "el objetivo no puede estar indexado")
+	}
+	if _, err := s.expect(":"); err != nil {
+		return err
+	}
+	body, err := parseExpr(s)
+	if err != nil {
+		return err
+	}
+	if _, err := s.expect(";"); err != nil {
+		return err
+	}
+	// Como en GLPK, se optimiza el primer objetivo
+	if p.objective == nil {
+		p.objective = &objDecl{minimize: kw.is("minimize"), name: name.text, tok: name, body: body}
+	}
+	return nil
+}
+
+func (p *program) constraint(s *stream) error {
+	name, err := s.ident()
+	if err != nil {
+		return err
+	}
+	d := &conDecl{name: name.text, tok: name}
+	if err := p.declare(name, d); err != nil {
+		return err
+	}
+	skipAlias(s)
+	if s.peek().is("{") {
+		if d.dom, err = parseDomain(s); err != nil {
+			return err
+		}
+	}
+	if _, err := s.expect(":"); err != nil {
+		return err
+	}
+	for {
+		e, err := parseExpr(s)
+		if err != nil {
+			return err
+		}
+		d.parts = append(d.parts, e)
+		t := s.next()
+		if t.is(";") {
+			break
+		}
+		cmp := t.text
+		switch {
+		case t.is("<="), t.is(">="):
+		case t.is("="), t.is("=="):
+			cmp = "="
+		default:
+			return errorf(t, "se esperaba <=, >= o = y se encontró %s", describe(t))
+		}
+		d.cmps = append(d.cmps, cmp)
+	}
+	switch {
+	case len(d.cmps) == 0:
+		return errorf(name, "la restricción %s no tiene <=, >= ni =", name.text)
+	case len(d.cmps) > 2:
+		return errorf(name, "la restricción %s tiene demasiadas comparaciones", name.text)
+	case len(d.cmps) == 2 && (d.cmps[0] != d.cmps[1] || d.cmps[0] == "="):
+		return errorf(name, "la restricción doble %s debe usar dos <= o dos >=", name.text)
+	}
+	p.cons = append(p.cons, d)
+	return nil
+}
+
+// parseDomain lee {i in I, j in 1..n: condición}.
+func parseDomain(s *stream) (*domain, error) {
+	if _, err := s.expect("{"); err != nil {
+		return nil, err
+	}
+	d := &domain{}
+	for {
+		var e domainEntry
+		if s.peek().kind == tIdent && s.peekAt(1).is("in") {
+			e.dummy = s.next().text
+			s.next()
+		} else if s.peek().is("(") {
+			return nil, errorf(s.peek(), "los índices compuestos (i,j) no están soportados")
+		}
+		set, err := parseSet(s)
+		if err != nil {
+			return nil, err
+		}
+		e.set = set
+		d.entries = append(d.entries, e)
+		if !s.accept(",") {
+			break
+		}
+	}
+	if s.accept(":") {
+		cond, err := parseLogical(s)
+		if err != nil {
+			return nil, err
+		}
+		d.cond = cond
+	}
+	if _, err := s.expect("}"); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// parseSet lee un nombre de conjunto, un rango a..b [by c] o una lista {a, b}.
+func parseSet(s *stream) (setExpr, error) {
+	if s.accept("{") {
+		lit := setLit{}
+		for !s.accept("}") {
+			e, err := parseExpr(s)
+			if err != nil {
+				return nil, err
+			}
+			lit.items = append(lit.items, e)
+			if !s.peek().is("}") {
+				if _, err := s.expect(","); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return lit, nil
+	}
+	start := s.peek()
+	e, err := parseExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	if s.accept("..") {
+		r := setRange{from: e}
+		if r.to, err = parseExpr(s); err != nil {
+			return nil, err
+		}
+		if s.accept("by") {
+			if r.by, err = parseExpr(s); err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	}
+	if r, ok := e.(ref); ok && len(r.idx) == 0 {
+		return setRef{name: r.name, tok: r.tok}, nil
+	}
+	return nil, errorf(start, "se esperaba un conjunto")
+}
+
+// parseLogical lee una condición con or, and, not y comparaciones.
+func parseLogical(s *stream) (expr, error) {
+	l, err := parseAnd(s)
+	if err != nil {
+		return nil, err
+	}
+	for s.peek().is("or") || s.peek().is("||") {
+		t := s.next()
+		r, err := parseAnd(s)
+		if err != nil {
+			return nil, err
+		}
+		l = condExpr{op: "or", l: l, r: r, tok: t}
+	}
+	return l, nil
+}
+
+func parseAnd(s *stream) (expr, error) {
+	l, err := parseNot(s)
+	if err != nil {
+		return nil, err
+	}
+	for s.peek().is("and") || s.peek().is("&&") {
+		t := s.next()
+		r, err := parseNot(s)
+		if err != nil {
+			return nil, err
+		}
+		l = condExpr{op: "and", l: l, r: r, tok: t}
+	}
+	return l, nil
+}
+
+func parseNot(s *stream) (expr, error) {
+	if t := s.peek(); t.is("not") {
+		s.next()
+		x, err := parseNot(s)
+		return condExpr{op: "not", l: x, tok: t}, err
+	}
+	if s.peek().is("(") {
+		// Paréntesis alrededor de una condición o de una expresión numérica
+		save := s.pos
+		s.next()
+		if c, err := parseLogical(s); err == nil && s.accept(")") {
+			if _, ok := c.(condExpr); ok {
+				return c, nil
+			}
+		}
+		s.pos = save
+	}
+	l, err := parseExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	t := s.peek()
+	switch {
+	case t.is("<"), t.is("<="), t.is(">="), t.is(">"):
+	case t.is("="), t.is("=="):
+		t.text = "="
+	case t.is("<>"), t.is("!="):
+		t.text = "<>"
+	default:
+		return nil, errorf(t, "se esperaba una comparación y se encontró %s", describe(t))
+	}
+	s.next()
+	r, err := parseExpr(s)
+	if err != nil {
+		return nil, err
+	}
+	return condExpr{op: t.text, l: l, r: r, tok: t}, nil
+}
+
+// parseExpr lee una expresión con + y -. Como en MathProg, sum se aplica a la
+// expresión multiplicativa que le sigue: sum{i in I} c[i] * x[i] + 1 suma 1 una vez.
+func parseExpr(s *stream) (expr, error) {
+	l, err := parseMul(s)
+	if err != nil {
+		return nil, err
+	}
+	for s.peek().is("+") || s.peek().is("-") {
+		t := s.next()
+		r, err := parseMul(s)
+		if err != nil {
+			return nil, err
+		}
+		l = binExpr{op: t.text, l: l, r: r, tok: t}
+	}
+	return l, nil
+}
+
+func parseMul(s *stream) (expr, error) {
+	l, err := parseUnary(s)
+	if err != nil {
+		return nil, err
+	}
+	for s.peek().is("*") || s.peek().is("/") {
+		t := s.next()
+		r, err := parseUnary(s)
+		if err != nil {
+			return nil, err
+		}
+		l = binExpr{op: t.text, l: l, r: r, tok: t}
+	}
+	return l, nil
+}
+
+func parseUnary(s *stream) (expr, error) {
+	switch t := s.peek(); {
+	case t.is("-"):
+		s.next()
+		x, err := parseUnary(s)
+		return negExpr{x}, err
+	case t.is("+"):
+		s.next()
+		return parseUnary(s)
+	case t.is("sum"):
+		s.next()
+		dom, err := parseDomain(s)
+		if err != nil {
+			return nil, err
+		}
+		body, err := parseMul(s)
+		return sumExpr{dom: dom, body: body}, err
+	}
+	return parsePower(s)
+}
+
+func parsePower(s *stream) (expr, error) {
+	base, err := parsePrimary(s)
+	if err != nil {
+		return nil, err
+	}
+	if t := s.peek(); t.is("^") || t.is("**") {
+		s.next()
+		exp, err := parseUnary(s)
+		return binExpr{op: "^", l: base, r: exp, tok: t}, err
+	}
+	return base, nil
+}
+
+func parsePrimary(s *stream) (expr, error) {
+	t := s.next()
+	switch t.kind {
+	case tNumber:
+		return numLit{t.num}, nil
+	case tString:
+		return strLit{t.text}, nil
+	case tIdent:
+		r := ref{name: t.text, tok: t}
+		if s.accept("[") {
+			for {
+				e, err := parseExpr(s)
+				if err != nil {
+					return nil, err
+				}
+				r.idx = append(r.idx, e)
+				if !s.accept(",") {
+					break
+				}
+			}
+			if _, err := s.expect("]"); err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	}
+	if t.is("(") {
+		e, err := parseExpr(s)
+		if err != nil {
+			return nil, err
+		}
+		_, err = s.expect(")")
+		return e, err
+	}
+	return nil, errorf(t, "se esperaba una expresión y se encontró %s", describe(t))
+}
+
+// data lee la sección de datos hasta "end;" o el fin del archivo.
+func (p *program) data(s *stream) error {
+	for {
+		t := s.next()
+		switch {
+		case t.kind == tEOF:
+			return nil
+		case t.is("end"):
+			s.accept(";")
+			return nil
+		case t.is("set"):
+			if err := p.readSet(s); err != nil {
+				return err
+			}
+		case t.is("param"):
+			if err := p.readParam(s); err != nil {
+				return err
+			}
+		default:
+			return errorf(t, "se esperaba set o param en la sección de datos y se encontró %s", describe(t))
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package graphical
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"autosimplex/internal/simplex"
+)
+
+// Tamaño del gráfico en píxeles y márgenes para los ejes.
+const (
+	width   = 520
+	height  = 520
+	marginL = 56
+	marginB = 48
+	marginT = 24
+	marginR = 24
+)
+
+// pixel es un punto en coordenadas de la imagen (y crece hacia abajo).
+type pixel struct{ X, Y float64 }
+
+// canvas es lo que necesita draw para dibujar; lo implementan el SVG y el PNG.
+type canvas interface {
+	polygon(pts []pixel, fill color.RGBA)
+	line(a, b pixel, c color.RGBA, width float64, dashed bool)
+	circle(p pixel, r float64, fill color.RGBA)
+	// text escribe s con el ancla "start", "middle" o "end" en p.
+	text(p pixel, s string, c color.RGBA, anchor string)
+}
+
+var (
+	black     = color.RGBA{31, 41, 55, 255}
+	gridColor = color.RGBA{226, 232, 240, 255}
+	fillColor = color.RGBA{14, 165, 233, 70}
+	isoColor  = color.RGBA{100, 116, 139, 255}
+	optColor  = color.RGBA{220, 38, 38, 255}
+	pathColor = color.RGBA{234, 88, 12, 255}
+	offColor  = color.RGBA{148, 163, 184, 255}
+	// Colores de las rectas, uno por restricción
+	palette = []color.RGBA{
+		{37, 99, 235, 255},
+		{22, 163, 74, 255},
+		{217, 119, 6, 255},
+		{147, 51, 234, 255},
+		{219, 39, 119, 255},
+		{13, 148, 136, 255},
+	}
+)
+
+// frame convierte coordenadas del problema en píxeles.
+type frame struct{ w Box }
+
+func (f frame) at(p Point) pixel {
+	plotW := float64(width - marginL - marginR)
+	plotH := float64(height - marginT - marginB)
+	return pixel{
+		X: marginL + p.X/f.w.MaxX*plotW,
+		Y: marginT + plotH - p.Y/f.w.MaxY*plotH,
+	}
+}
+
+// draw dibuja la geometría completa: cuadrícula, región, rectas, curvas de
+// nivel, ejes y vértices, en ese orden para que lo importante quede encima.
+func draw(cv canvas, g Geometry) {
+	if g.Solid != nil {
+		drawSolid(cv, g)
+		return
+	}
+	f := frame{g.Window}
+	const ticks = 5
+
+	// Cuadrícula y marcas de los ejes
+	for k := 0; k <= ticks; k++ {
+		x := g.Window.MaxX * float64(k) / ticks
+		y := g.Window.MaxY * float64(k) / ticks
+		cv.line(f.at(Point{x, 0}), f.at(Point{x, g.Window.MaxY}), gridColor, 1, false)
+		cv.line(f.at(Point{0, y}), f.at(Point{g.Window.MaxX, y}), gridColor, 1, false)
+		px, py := f.at(Point{x, 0}), f.at(Point{0, y})
+		cv.text(pixel{px.X, px.Y + 16}, number(x), black, "middle")
+		if k > 0 {
+			cv.text(pixel{py.X - 6, py.Y + 4}, number(y), black, "end")
+		}
+	}
+
+	// Una región de un solo lado (restricciones de igualdad) se dibuja como trazo
+	switch {
+	case len(g.Region) >= 3:
+		pts := make([]pixel, len(g.Region))
+		for i, p := range g.Region {
+			pts[i] = f.at(p)
+		}
+		cv.polygon(pts, fillColor)
+	case len(g.Region) == 2:
+		solid := fillColor
+		solid.A = 255
+		cv.line(f.at(g.Region[0]), f.at(g.Region[1]), solid, 6, false)
+	}
+
+	for _, l := range g.Lines {
+		if !l.Visible {
+			continue
+		}
+		c := palette[l.Row%len(palette)]
+		a, b := f.at(l.From), f.at(l.To)
+		cv.line(a, b, c, 2, false)
+		// El nombre va en el extremo más alto del tramo, apenas adentro
+		end := a
+		if b.Y < a.Y || (b.Y == a.Y && b.X > a.X) {
+			end = b
+		}
+		cv.text(pixel{math.Min(end.X+4, width-marginR), math.Max(end.Y-4, marginT+10)}, l.Name, c, "start")
+	}
+
+	for _, iso := range g.IsoLines {
+		a, b := f.at(iso.From), f.at(iso.To)
+		cv.line(a, b, isoColor, 1.5, true)
+		// El valor va en el extremo más alto, donde las curvas están separadas
+		end := a
+		if b.Y < a.Y {
+			end = b
+		}
+		cv.text(pixel{end.X + 4, math.Max(end.Y+14, marginT+14)}, "Z = "+number(iso.Value), isoColor, "start")
+	}
+
+	// Ejes
+	origin := f.at(Point{0, 0})
+	cv.line(origin, f.at(Point{g.Window.MaxX, 0}), black, 1.5, false)
+	cv.line(origin, f.at(Point{0, g.Window.MaxY}), black, 1.5, false)
+	cv.text(pixel{width - marginR, height - 8}, g.Names[0], black, "end")
+	cv.text(pixel{marginL - 8, marginT - 8}, g.Names[1], black, "middle")
+
+	for _, v := range g.Vertices {
+		cv.circle(f.at(v.Point), 3.5, black)
+	}
+	drawPath(cv, func(p PathPoint) pixel { return f.at(p.Point) }, g.Path)
+	if g.Optimum != nil {
+		p := f.at(g.Optimum.Point)
+		cv.circle(p, 6, optColor)
+		label := fmt.Sprintf("(%s, %s)  Z* = %s", number(g.Optimum.X), number(g.Optimum.Y), number(g.Optimum.Value))
+		anchor, dx := "start", 10.0
+		if p.X > width/2 {
+			anchor, dx = "end", -10
+		}
+		cv.text(pixel{p.X + dx, p.Y - 10}, label, optColor, anchor)
+	}
+}
+
+// number usa el formato del resto de los informes, con el guion ASCII que
+// tienen todas las fuentes.
+func number(v float64) string {
+	return strings.ReplaceAll(simplex.FormatNumber(v), "−", "-")
+}
+
+// drawPath dibuja el recorrido del simplex: una flecha por cada pivote que mueve
+// el punto y, junto a cada punto visitado, las iteraciones en que estuvo ahí. Los
+// puntos con artificiales en la base (fuera de la región) van en gris. at ubica
+// cada punto en la imagen.
+func drawPath(cv canvas, at func(PathPoint) pixel, path []PathPoint) {
+	for k := 1; k < len(path); k++ {
+		if !path[k].Degenerate {
+			arrow(cv, at(path[k-1]), at(path[k]), pathColor)
+		}
+	}
+	var labels []string
+	for k, p := range path {
+		labels = append(labels, strconv.Itoa(p.Iteration))
+		if k+1 < len(path) && path[k+1].Degenerate {
+			continue
+		}
+		c := pathColor
+		if !p.Feasible {
+			c = offColor
+		}
+		px := at(p)
+		cv.circle(px, 4.5, c)
+		cv.text(pixel{px.X + 8, px.Y + 16}, strings.Join(labels, ","), c, "start")
+		labels = labels[:0]
+	}
+}
+
+// view proyecta el espacio en la imagen con una perspectiva isométrica: x1 va
+// hacia abajo a la izquierda, x2 hacia abajo a la derecha y x3 hacia arriba.
+// Cada eje se escala a su lado de la ventana.
+type view struct{ w [3]float64 }
+
+func (v view) at(p Point3) pixel {
+	cos30 := math.Sqrt(3) / 2
+	u1, u2, u3 := p.X/v.w[0], p.Y/v.w[1], p.Z/v.w[2]
+	// Con los tres valores entre 0 y 1, x queda entre -cos30 y cos30 e y entre
+	// -1 y 1; y = -1 es la esquina (1, 1, 0) de la ventana, que el poliedro no
+	// alcanza por el margen, así que se dibuja desde y = -0.75
+	x := (u2 - u1) * cos30
+	y := u3 - (u1+u2)/2
+	plotW := float64(width - marginL - marginR)
+	plotH := float64(height - marginT - marginB)
+	scale := math.Min(plotW/(2*cos30), plotH/1.75)
+	return pixel{
+		X: marginL + plotW/2 + x*scale,
+		Y: marginT + (1-y)*scale,
+	}
+}
+
+// drawSolid dibuja un problema de tres variables: las caras del poliedro
+// (translúcidas, para que se vean las de atrás), sus aristas, los ejes, los
+// vértices, el recorrido del simplex y el óptimo.
+func drawSolid(cv canvas, g Geometry) {
+	s := g.Solid
+	v := view{s.Window}
+	pointOf := func(p PathPoint) Point3 {
+		if len(p.Values) < 3 {
+			return Point3{p.X, p.Y, 0}
+		}
+		return Point3{p.Values[0], p.Values[1], p.Values[2]}
+	}
+
+	for _, f := range s.Faces {
+		pts := make([]pixel, len(f.Points))
+		for i, p := range f.Points {
+			pts[i] = v.at(p)
+		}
+		c := fillColor
+		if f.Row >= 0 {
+			c = palette[f.Row%len(palette)]
+			c.A = 50
+		}
+		cv.polygon(pts, c)
+	}
+	for _, e := range s.Edges {
+		cv.line(v.at(e.From), v.at(e.To), black, 1.2, false)
+	}
+
+	// Ejes, con el nombre y el extremo de la ventana
+	origin := v.at(Point3{})
+	ends := [3]Point3{{X: s.Window[0]}, {Y: s.Window[1]}, {Z: s.Window[2]}}
+	for j, e := range ends {
+		p := v.at(e)
+		cv.line(origin, p, black, 1.5, false)
+		switch j {
+		case 0:
+			cv.text(pixel{p.X - 6, p.Y + 4}, s.Names[j], black, "end")
+			cv.text(pixel{p.X - 6, p.Y + 18}, number(s.Window[j]), black, "end")
+		case 1:
+			cv.text(pixel{p.X + 6, p.Y + 4}, s.Names[j], black, "start")
+			cv.text(pixel{p.X + 6, p.Y + 18}, number(s.Window[j]), black, "start")
+		default:
+			cv.text(pixel{p.X, p.Y - 8}, s.Names[j], black, "middle")
+			cv.text(pixel{p.X - 6, p.Y + 12}, number(s.Window[j]), black, "end")
+		}
+	}
+
+	// El nombre de cada restricción va en el centro de su cara
+	for _, f := range s.Faces {
+		if f.Row < 0 {
+			continue
+		}
+		var c Point3
+		for _, p := range f.Points {
+			c.X += p.X / float64(len(f.Points))
+			c.Y += p.Y / float64(len(f.Points))
+			c.Z += p.Z / float64(len(f.Points))
+		}
+		col := palette[f.Row%len(palette)]
+		cv.text(v.at(c), f.Name, col, "middle")
+	}
+
+	for _, vx := range s.Vertices {
+		cv.circle(v.at(vx.Point3), 3.5, black)
+	}
+	drawPath(cv, func(p PathPoint) pixel { return v.at(pointOf(p)) }, g.Path)
+	if s.Optimum != nil {
+		p := v.at(s.Optimum.Point3)
+		cv.circle(p, 6, optColor)
+		label := s.Optimum.label() + "  Z* = " + number(s.Optimum.Value)
+		anchor, dx := "start", 10.0
+		if p.X > width/2 {
+			anchor, dx = "end", -10
+		}
+		cv.text(pixel{p.X + dx, p.Y - 10}, label, optColor, anchor)
+	}
+}
+
+// arrow dibuja una flecha de a a b con la punta apoyada en b.
+func arrow(cv canvas, a, b pixel, c color.RGBA) {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length := math.Hypot(dx, dy)
+	if length < 1 {
+		return
+	}
+	ux, uy := dx/length, dy/length
+	const head, wing, gap = 12.0, 5.0, 5.0
+	tip := pixel{b.X - ux*gap, b.Y - uy*gap}
+	base := pixel{tip.X - ux*head, tip.Y - uy*head}
+	cv.line(a, base, c, 2.5, false)
+	cv.polygon([]pixel{
+		tip,
+		{base.X - uy*wing, base.Y + ux*wing},
+		{base.X + uy*wing, base.Y - ux*wing},
+	}, c)
+}
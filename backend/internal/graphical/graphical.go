@@ -0,0 +1,520 @@
+// Package graphical resuelve por el método gráfico los problemas con dos
+// variables de decisión: calcula las rectas de cada restricción, la región
+// factible (recortada a una ventana si no está acotada), sus vértices, algunas
+// curvas de nivel del objetivo y el vértice óptimo. Con tres variables calcula
+// el poliedro factible (ver Solid) y lo dibuja en perspectiva. La geometría se
+// publica como JSON y se dibuja en SVG (para la web) o PNG (para el PDF).
+package graphical
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// ErrDimension indica que el problema no tiene dos ni tres variables.
+var ErrDimension = errors.New("el método gráfico solo se aplica a problemas con 2 o 3 variables de decisión")
+
+const eps = 1e-9
+
+// Point es un punto del plano (x1, x2).
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Line es la recta a·x = b que limita una restricción, con el tramo visible en
+// la ventana. Una fila de rango aporta dos rectas.
+type Line struct {
+	// Row es el índice (base 0) de la restricción.
+	Row  int        `json:"row"`
+	Name string     `json:"name"`
+	A    [2]float64 `json:"a"`
+	B    float64    `json:"b"`
+	// Sign es "<=", ">=" o "=": el semiplano factible respecto de la recta.
+	Sign string `json:"sign"`
+	// From y To son los extremos del tramo visible; Visible es false si la recta
+	// no cruza la ventana.
+	From    Point `json:"from"`
+	To      Point `json:"to"`
+	Visible bool  `json:"visible"`
+}
+
+// Vertex es un vértice de la región factible con el valor del objetivo.
+type Vertex struct {
+	Point
+	Value float64 `json:"value"`
+	// Rows son las restricciones activas en el vértice; -1 y -2 son los ejes
+	// x1 = 0 y x2 = 0.
+	Rows []int `json:"rows"`
+}
+
+// IsoLine es una curva de nivel c·x = Value del objetivo.
+type IsoLine struct {
+	Value float64 `json:"value"`
+	From  Point   `json:"from"`
+	To    Point   `json:"to"`
+}
+
+// Box es la ventana del gráfico.
+type Box struct {
+	MaxX float64 `json:"max_x"`
+	MaxY float64 `json:"max_y"`
+}
+
+// Geometry es el resultado del método gráfico.
+type Geometry struct {
+	Names     [2]string  `json:"names"`
+	Objective [2]float64 `json:"objective"`
+	Minimize  bool       `json:"minimize"`
+	Window    Box        `json:"window"`
+	Lines     []Line     `json:"lines"`
+	// Region es el polígono factible en sentido antihorario, recortado a la ventana.
+	Region []Point `json:"region"`
+	// Vertices son los vértices verdaderos de la región (no los cortes con la ventana).
+	Vertices []Vertex  `json:"vertices"`
+	Bounded  bool      `json:"bounded"`
+	IsoLines []IsoLine `json:"iso_lines"`
+	// Optimum es el vértice óptimo, si existe.
+	Optimum *Vertex `json:"optimum,omitempty"`
+	// Alternative indica que hay óptimos alternativos sobre un lado de la región.
+	Alternative bool `json:"alternative,omitempty"`
+	// Status es optimal, infeasible o unbounded, como en simplex.Result.
+	Status string `json:"status"`
+	// Path es el recorrido del simplex, una solución básica por tabla; solo lo
+	// completa Trace.
+	Path []PathPoint `json:"path,omitempty"`
+	// Solid es la geometría de un problema de tres variables. En ese caso solo
+	// se completan además Minimize, Bounded, Alternative, Status y Path.
+	Solid *Solid `json:"solid,omitempty"`
+}
+
+// halfPlane es a·x <= b, con la restricción que lo originó.
+type halfPlane struct {
+	a   [2]float64
+	b   float64
+	row int
+}
+
+// Solve aplica el método gráfico a req, que debe tener dos o tres variables.
+func Solve(req models.SimplexRequest) (Geometry, error) {
+	return solve(req, nil)
+}
+
+// Trace es Solve más el recorrido del simplex de res sobre el gráfico. La
+// ventana se agranda si hace falta para que entren los puntos visitados.
+func Trace(req models.SimplexRequest, res simplex.Result) (Geometry, error) {
+	path := Path(res, req.Objective.Coefficients)
+	g, err := solve(req, path)
+	if err != nil {
+		return g, err
+	}
+	g.Path = path
+	return g, nil
+}
+
+// solve calcula la geometría; la ventana debe contener los puntos de path.
+func solve(req models.SimplexRequest, path []PathPoint) (Geometry, error) {
+	n := req.Objective.N
+	if n != len(req.Objective.Coefficients) || n < 2 || n > 3 {
+		return Geometry{}, ErrDimension
+	}
+	if n == 3 {
+		extra := make([]Point3, 0, len(path))
+		for _, p := range path {
+			if len(p.Values) == 3 {
+				extra = append(extra, Point3{p.Values[0], p.Values[1], p.Values[2]})
+			}
+		}
+		return solve3(req, extra)
+	}
+	extra := make([]Point, len(path))
+	for i, p := range path {
+		extra[i] = p.Point
+	}
+	cons := req.Constraints
+	if len(cons.Vars) != cons.Rows*cons.Cols || cons.Cols != 3 {
+		return Geometry{}, fmt.Errorf("la matriz de restricciones debe tener 3 columnas por fila")
+	}
+	g := Geometry{
+		Objective: [2]float64{req.Objective.Coefficients[0], req.Objective.Coefficients[1]},
+		Minimize:  strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize"),
+		Lines:     []Line{},
+		Region:    []Point{},
+		Vertices:  []Vertex{},
+		IsoLines:  []IsoLine{},
+	}
+	for j := range 2 {
+		g.Names[j] = fmt.Sprintf("x%d", j+1)
+		if j < len(req.Objective.Names) && req.Objective.Names[j] != "" {
+			g.Names[j] = req.Objective.Names[j]
+		}
+	}
+
+	// Semiplanos a·x <= b; x1 >= 0 y x2 >= 0 llevan las filas -1 y -2
+	planes := []halfPlane{{a: [2]float64{-1, 0}, row: -1}, {a: [2]float64{0, -1}, row: -2}}
+	for i := range cons.Rows {
+		a := [2]float64{cons.Vars[i*3], cons.Vars[i*3+1]}
+		b := cons.Vars[i*3+2]
+		name := fmt.Sprintf("R%d", i+1)
+		if i < len(cons.Names) && cons.Names[i] != "" {
+			name = cons.Names[i]
+		}
+		sign := "<="
+		if i < len(cons.Signs) {
+			sign = cons.Signs[i]
+		}
+		neg := [2]float64{-a[0], -a[1]}
+		switch sign {
+		case ">=":
+			planes = append(planes, halfPlane{a: neg, b: -b, row: i})
+		case "=":
+			planes = append(planes, halfPlane{a: a, b: b, row: i}, halfPlane{a: neg, b: -b, row: i})
+		case "range":
+			lo := 0.0
+			if i < len(cons.Lower) {
+				lo = cons.Lower[i]
+			}
+			planes = append(planes, halfPlane{a: a, b: b, row: i}, halfPlane{a: neg, b: -lo, row: i})
+			g.Lines = append(g.Lines, Line{Row: i, Name: name, A: a, B: lo, Sign: ">="})
+			sign = "<="
+		default:
+			planes = append(planes, halfPlane{a: a, b: b, row: i})
+			sign = "<="
+		}
+		g.Lines = append(g.Lines, Line{Row: i, Name: name, A: a, B: b, Sign: sign})
+	}
+
+	g.Vertices = vertices(planes, g.Objective)
+	g.Window = window(g.Vertices, g.Lines, extra)
+	for i := range g.Lines {
+		l := &g.Lines[i]
+		l.From, l.To, l.Visible = clipLine(l.A, l.B, g.Window)
+	}
+	g.Region = region(planes, g.Window)
+	// Si la región es acotada, la ventana (con margen) la contiene entera; si
+	// toca el borde lejano es porque sigue indefinidamente
+	g.Bounded = len(g.Region) > 0
+	for _, p := range g.Region {
+		if p.X >= g.Window.MaxX-eps || p.Y >= g.Window.MaxY-eps {
+			g.Bounded = false
+		}
+	}
+
+	switch {
+	case len(g.Vertices) == 0:
+		g.Status = "infeasible"
+	case !g.Bounded && improvingRay(planes, g.Objective, g.Minimize):
+		g.Status = "unbounded"
+	default:
+		g.Status = "optimal"
+		best := 0
+		for k, v := range g.Vertices {
+			if better(v.Value, g.Vertices[best].Value, g.Minimize) {
+				best = k
+			}
+		}
+		opt := g.Vertices[best]
+		g.Optimum = &opt
+		for k, v := range g.Vertices {
+			if k != best && math.Abs(v.Value-opt.Value) <= eps*math.Max(1, math.Abs(opt.Value)) {
+				g.Alternative = true
+			}
+		}
+	}
+	g.IsoLines = isoLines(g)
+	return g, nil
+}
+
+func better(v, than float64, minimize bool) bool {
+	if minimize {
+		return v < than-eps
+	}
+	return v > than+eps
+}
+
+// feasible indica si p cumple todos los semiplanos, con tolerancia relativa.
+func feasible(planes []halfPlane, p Point) bool {
+	for _, h := range planes {
+		lhs := h.a[0]*p.X + h.a[1]*p.Y
+		if lhs > h.b+1e-7*math.Max(1, math.Abs(h.b)) {
+			return false
+		}
+	}
+	return true
+}
+
+// vertices intersecta cada par de rectas y se queda con los puntos factibles,
+// sin repetir. Como la región está en el primer cuadrante, si no es vacía tiene
+// al menos un vértice.
+func vertices(planes []halfPlane, c [2]float64) []Vertex {
+	var out []Vertex
+	for i := range planes {
+		for j := i + 1; j < len(planes); j++ {
+			p, ok := intersect(planes[i], planes[j])
+			if !ok || !feasible(planes, p) {
+				continue
+			}
+			p = Point{clean(p.X), clean(p.Y)}
+			k := find(out, p)
+			if k < 0 {
+				out = append(out, Vertex{Point: p, Value: c[0]*p.X + c[1]*p.Y})
+				k = len(out) - 1
+			}
+			for _, row := range []int{planes[i].row, planes[j].row} {
+				if !slices.Contains(out[k].Rows, row) {
+					out[k].Rows = append(out[k].Rows, row)
+				}
+			}
+		}
+	}
+	for k := range out {
+		sort.Ints(out[k].Rows)
+	}
+	// Orden estable: por ángulo alrededor del centro, como el polígono
+	sortCCW(out)
+	if out == nil {
+		out = []Vertex{}
+	}
+	return out
+}
+
+// find devuelve la posición del vértice p en vs, o -1.
+func find(vs []Vertex, p Point) int {
+	for k, v := range vs {
+		if math.Abs(v.X-p.X) <= 1e-7*math.Max(1, math.Abs(p.X)) && math.Abs(v.Y-p.Y) <= 1e-7*math.Max(1, math.Abs(p.Y)) {
+			return k
+		}
+	}
+	return -1
+}
+
+// clean redondea el ruido de punto flotante (0.30000000000000004, -0).
+func clean(v float64) float64 {
+	r := math.Round(v*1e9) / 1e9
+	if r == 0 {
+		return 0
+	}
+	return r
+}
+
+// intersect resuelve el sistema de las dos rectas por Cramer.
+func intersect(p, q halfPlane) (Point, bool) {
+	det := p.a[0]*q.a[1] - p.a[1]*q.a[0]
+	if math.Abs(det) < eps {
+		return Point{}, false
+	}
+	return Point{
+		X: (p.b*q.a[1] - p.a[1]*q.b) / det,
+		Y: (p.a[0]*q.b - p.b*q.a[0]) / det,
+	}, true
+}
+
+// window elige una ventana que contiene todos los vértices, los puntos extra y
+// los cortes de las rectas con los ejes, con un 20 % de margen.
+func window(vs []Vertex, lines []Line, extra []Point) Box {
+	maxX, maxY := 0.0, 0.0
+	for _, v := range vs {
+		maxX, maxY = math.Max(maxX, v.X), math.Max(maxY, v.Y)
+	}
+	for _, p := range extra {
+		maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+	}
+	for _, l := range lines {
+		if math.Abs(l.A[0]) > eps {
+			if x := l.B / l.A[0]; x > 0 {
+				maxX = math.Max(maxX, x)
+			}
+		}
+		if math.Abs(l.A[1]) > eps {
+			if y := l.B / l.A[1]; y > 0 {
+				maxY = math.Max(maxY, y)
+			}
+		}
+	}
+	if maxX <= eps {
+		maxX = math.Max(maxY, 1)
+	}
+	if maxY <= eps {
+		maxY = math.Max(maxX, 1)
+	}
+	return Box{MaxX: niceCeil(maxX * 1.2), MaxY: niceCeil(maxY * 1.2)}
+}
+
+// niceCeil redondea hacia arriba a 1, 2, 2.5 o 5 por una potencia de 10.
+func niceCeil(v float64) float64 {
+	exp := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, m := range []float64{1, 2, 2.5, 5, 10} {
+		if m*exp >= v-eps {
+			return m * exp
+		}
+	}
+	return 10 * exp
+}
+
+// clipLine devuelve el tramo de a·x = b dentro de la ventana.
+func clipLine(a [2]float64, b float64, w Box) (Point, Point, bool) {
+	var pts []Point
+	add := func(p Point) {
+		if p.X < -eps || p.Y < -eps || p.X > w.MaxX+eps || p.Y > w.MaxY+eps {
+			return
+		}
+		for _, q := range pts {
+			if math.Abs(q.X-p.X) < 1e-9 && math.Abs(q.Y-p.Y) < 1e-9 {
+				return
+			}
+		}
+		pts = append(pts, Point{clean(p.X), clean(p.Y)})
+	}
+	if math.Abs(a[1]) > eps {
+		add(Point{0, b / a[1]})
+		add(Point{w.MaxX, (b - a[0]*w.MaxX) / a[1]})
+	}
+	if math.Abs(a[0]) > eps {
+		add(Point{b / a[0], 0})
+		add(Point{(b - a[1]*w.MaxY) / a[0], w.MaxY})
+	}
+	if len(pts) < 2 {
+		return Point{}, Point{}, false
+	}
+	return pts[0], pts[len(pts)-1], true
+}
+
+// region recorta el rectángulo de la ventana con cada semiplano
+// (Sutherland–Hodgman); el resultado queda en sentido antihorario.
+func region(planes []halfPlane, w Box) []Point {
+	poly := []Point{{0, 0}, {w.MaxX, 0}, {w.MaxX, w.MaxY}, {0, w.MaxY}}
+	for _, h := range planes {
+		if len(poly) == 0 {
+			break
+		}
+		inside := func(p Point) bool { return h.a[0]*p.X+h.a[1]*p.Y <= h.b+eps }
+		var out []Point
+		for i, cur := range poly {
+			prev := poly[(i+len(poly)-1)%len(poly)]
+			if inside(cur) {
+				if !inside(prev) {
+					out = append(out, cut(h, prev, cur))
+				}
+				out = append(out, cur)
+			} else if inside(prev) {
+				out = append(out, cut(h, prev, cur))
+			}
+		}
+		poly = out
+	}
+	// Quitar puntos repetidos consecutivos (cortes por un vértice)
+	out := []Point{}
+	for _, p := range poly {
+		p = Point{clean(p.X), clean(p.Y)}
+		if len(out) > 0 && near(out[len(out)-1], p) {
+			continue
+		}
+		out = append(out, p)
+	}
+	if len(out) > 1 && near(out[0], out[len(out)-1]) {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func near(p, q Point) bool {
+	return math.Abs(p.X-q.X) < 1e-9 && math.Abs(p.Y-q.Y) < 1e-9
+}
+
+// cut es el punto donde el segmento pq cruza la recta del semiplano h.
+func cut(h halfPlane, p, q Point) Point {
+	fp := h.a[0]*p.X + h.a[1]*p.Y - h.b
+	fq := h.a[0]*q.X + h.a[1]*q.Y - h.b
+	t := fp / (fp - fq)
+	return Point{p.X + t*(q.X-p.X), p.Y + t*(q.Y-p.Y)}
+}
+
+// improvingRay indica si la región tiene una dirección de recesión d (A·d <= 0,
+// d >= 0) que mejora el objetivo. En el plano los rayos extremos del cono son
+// los ejes o direcciones paralelas a alguna recta.
+func improvingRay(planes []halfPlane, c [2]float64, minimize bool) bool {
+	cands := [][2]float64{{1, 0}, {0, 1}}
+	for _, h := range planes {
+		cands = append(cands, [2]float64{h.a[1], -h.a[0]}, [2]float64{-h.a[1], h.a[0]})
+	}
+	for _, d := range cands {
+		if d[0] == 0 && d[1] == 0 {
+			continue
+		}
+		ok := true
+		for _, h := range planes {
+			if h.a[0]*d[0]+h.a[1]*d[1] > eps {
+				ok = false
+				break
+			}
+		}
+		gain := c[0]*d[0] + c[1]*d[1]
+		if minimize {
+			gain = -gain
+		}
+		if ok && gain > eps {
+			return true
+		}
+	}
+	return false
+}
+
+// isoLines devuelve tres curvas de nivel: la del óptimo (o la del mejor vértice)
+// y dos anteriores, repartidas entre el peor vértice y ese valor.
+func isoLines(g Geometry) []IsoLine {
+	c := g.Objective
+	if math.Abs(c[0]) < eps && math.Abs(c[1]) < eps || len(g.Vertices) == 0 {
+		return []IsoLine{}
+	}
+	best, worst := g.Vertices[0].Value, g.Vertices[0].Value
+	for _, v := range g.Vertices {
+		if better(v.Value, best, g.Minimize) {
+			best = v.Value
+		}
+		if better(worst, v.Value, g.Minimize) {
+			worst = v.Value
+		}
+	}
+	if g.Optimum != nil {
+		best = g.Optimum.Value
+	}
+	if math.Abs(best-worst) < eps {
+		// Un solo vértice o todos con el mismo valor: tomar valores peores
+		worst = best - math.Max(1, math.Abs(best))
+		if g.Minimize {
+			worst = best + math.Max(1, math.Abs(best))
+		}
+	}
+	out := []IsoLine{}
+	for _, f := range []float64{1.0 / 3, 2.0 / 3, 1} {
+		v := clean(worst + f*(best-worst))
+		if from, to, ok := clipLine(c, v, g.Window); ok {
+			out = append(out, IsoLine{Value: v, From: from, To: to})
+		}
+	}
+	return out
+}
+
+// sortCCW ordena los vértices en sentido antihorario alrededor de su centro.
+func sortCCW(vs []Vertex) {
+	if len(vs) < 2 {
+		return
+	}
+	var cx, cy float64
+	for _, v := range vs {
+		cx += v.X
+		cy += v.Y
+	}
+	cx /= float64(len(vs))
+	cy /= float64(len(vs))
+	sort.SliceStable(vs, func(i, j int) bool {
+		return math.Atan2(vs[i].Y-cy, vs[i].X-cx) < math.Atan2(vs[j].Y-cy, vs[j].X-cx)
+	})
+}
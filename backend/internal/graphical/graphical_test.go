@@ -0,0 +1,288 @@
+package graphical
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"slices"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func request(coefs []float64, vars []float64, signs ...string) models.SimplexRequest {
+	rows := len(vars) / 3
+	return models.SimplexRequest{
+		Objective:   models.Objective{N: 2, Coefficients: coefs},
+		Constraints: models.Constraints{Rows: rows, Cols: 3, Vars: vars, Signs: signs},
+	}
+}
+
+func TestSolve(t *testing.T) {
+	// max 3 x1 + 2 x2 s.a. x1 + x2 <= 4, x1 + 3 x2 <= 6
+	g, err := Solve(request([]float64{3, 2}, []float64{1, 1, 4, 1, 3, 6}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "optimal", g.Status)
+	assert.True(t, g.Bounded)
+	assert.Equal(t, Box{MaxX: 10, MaxY: 5}, g.Window)
+	pts := make([]Point, len(g.Vertices))
+	for i, v := range g.Vertices {
+		pts[i] = v.Point
+	}
+	assert.ElementsMatch(t, []Point{{0, 0}, {4, 0}, {3, 1}, {0, 2}}, pts)
+	assert.Len(t, g.Region, 4)
+	if assert.NotNil(t, g.Optimum) {
+		assert.Equal(t, Point{4, 0}, g.Optimum.Point)
+		assert.Equal(t, 12.0, g.Optimum.Value)
+		assert.Equal(t, []int{-2, 0}, g.Optimum.Rows)
+	}
+	assert.False(t, g.Alternative)
+
+	assert.Len(t, g.Lines, 2)
+	assert.Equal(t, Line{Row: 0, Name: "R1", A: [2]float64{1, 1}, B: 4, Sign: "<=", From: Point{0, 4}, To: Point{4, 0}, Visible: true}, g.Lines[0])
+	if assert.Len(t, g.IsoLines, 3) {
+		assert.Equal(t, 12.0, g.IsoLines[2].Value)
+		assert.Equal(t, 4.0, g.IsoLines[0].Value)
+	}
+}
+
+func TestSolveCases(t *testing.T) {
+	// Minimizar con una fila >=: óptimo en (1, 0)
+	req := request([]float64{2, 3}, []float64{1, 1, 1})
+	req.Constraints.Signs = []string{">="}
+	req.Objective.Type = "minimize"
+	g, err := Solve(req)
+	assert.NoError(t, err)
+	assert.False(t, g.Bounded)
+	assert.Equal(t, "optimal", g.Status)
+	assert.Equal(t, Point{1, 0}, g.Optimum.Point)
+
+	// Maximizar la misma región: no acotado
+	req.Objective.Type = "maximize"
+	g, _ = Solve(req)
+	assert.Equal(t, "unbounded", g.Status)
+	assert.Nil(t, g.Optimum)
+
+	// x1 + x2 <= 1 y x1 + x2 >= 2: infactible
+	g, _ = Solve(request([]float64{1, 1}, []float64{1, 1, 1, 1, 1, 2}, "<=", ">="))
+	assert.Equal(t, "infeasible", g.Status)
+	assert.Empty(t, g.Vertices)
+	assert.Empty(t, g.Region)
+
+	// Objetivo paralelo a x1 + x2 <= 4: óptimos alternativos
+	g, _ = Solve(request([]float64{1, 1}, []float64{1, 1, 4}))
+	assert.True(t, g.Alternative)
+	assert.Equal(t, 4.0, g.Optimum.Value)
+
+	// Fila de rango 1 <= x1 + x2 <= 4: dos rectas
+	req = request([]float64{1, 0}, []float64{1, 1, 4}, "range")
+	req.Constraints.Lower = []float64{1}
+	g, _ = Solve(req)
+	assert.Len(t, g.Lines, 2)
+	assert.Len(t, g.Vertices, 4)
+
+	_, err = Solve(models.SimplexRequest{Objective: models.Objective{N: 4, Coefficients: []float64{1, 2, 3, 4}}})
+	assert.ErrorIs(t, err, ErrDimension)
+}
+
+func TestWriteSVG(t *testing.T) {
+	req := request([]float64{3, 2}, []float64{1, 1, 4, 1, 3, 6})
+	req.Constraints.Names = []string{"madera<1>", ""}
+	g, err := Solve(req)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSVG(&buf, g))
+	out := buf.String()
+	// Es XML válido
+	dec := xml.NewDecoder(strings.NewReader(out))
+	for {
+		if _, err := dec.Token(); err != nil {
+			assert.Equal(t, "EOF", err.Error())
+			break
+		}
+	}
+	assert.Contains(t, out, "<polygon")
+	assert.Contains(t, out, "madera&lt;1&gt;")
+	assert.Contains(t, out, "Z* = 12")
+	assert.Equal(t, 3, strings.Count(out, "stroke-dasharray"))
+}
+
+func TestPNG(t *testing.T) {
+	g, err := Solve(request([]float64{3, 2}, []float64{1, 1, 4, 1, 3, 6}))
+	assert.NoError(t, err)
+	data, err := PNG(g)
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, 2*width, img.Bounds().Dx())
+
+	// El interior de la región está pintado y el exterior no
+	f := frame{g.Window}
+	inside, outside := f.at(Point{1, 0.5}), f.at(Point{8, 4})
+	r, gr, b, _ := img.At(int(inside.X*2), int(inside.Y*2)).RGBA()
+	assert.NotEqual(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, gr, b})
+	r, gr, b, _ = img.At(int(outside.X*2)+3, int(outside.Y*2)+3).RGBA()
+	assert.Equal(t, [3]uint32{0xffff, 0xffff, 0xffff}, [3]uint32{r, gr, b})
+}
+
+func TestTrace(t *testing.T) {
+	// max 3 x1 + 5 x2 s.a. x1 <= 4, 2 x2 <= 12, 3 x1 + 2 x2 <= 18, x1 + x2 >= 1:
+	// la primera tabla tiene una artificial en la base y el origen no es factible
+	req := request([]float64{3, 5}, []float64{1, 0, 4, 0, 2, 12, 3, 2, 18, 1, 1, 1}, "<=", "<=", "<=", ">=")
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(4, 3, req.Constraints.Vars),
+		Signs:       req.Constraints.Signs,
+	})
+	g, err := Trace(req, res)
+	assert.NoError(t, err)
+
+	pts := make([]Point, len(g.Path))
+	for i, p := range g.Path {
+		pts[i] = p.Point
+		assert.Equal(t, i, p.Iteration)
+	}
+	assert.Equal(t, []Point{{0, 0}, {0, 1}, {0, 6}, {2, 6}}, pts)
+	assert.False(t, g.Path[0].Feasible)
+	assert.True(t, g.Path[3].Feasible)
+	assert.Equal(t, 36.0, g.Path[3].Value)
+	assert.Equal(t, g.Optimum.Point, g.Path[len(g.Path)-1].Point)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSVG(&buf, g))
+	// Una flecha (punta triangular) por cada pivote
+	assert.Equal(t, 3, strings.Count(buf.String(), `<polygon points="`)-1)
+}
+
+func TestPathDegenerate(t *testing.T) {
+	res := simplex.Result{
+		Variables: []simplex.Variable{{Index: 1, Kind: simplex.Decision}, {Index: 2, Kind: simplex.Decision}, {Index: 3, Kind: simplex.Slack}},
+		Steps: []simplex.SimplexStep{
+			{Iteration: 0, BaseVariables: []int{3}, BVector: []float64{0}},
+			{Iteration: 1, BaseVariables: []int{1}, BVector: []float64{0}},
+			{Iteration: 2, BaseVariables: []int{2}, BVector: []float64{2}},
+		},
+	}
+	path := Path(res, []float64{1, 1})
+	assert.False(t, path[0].Degenerate)
+	assert.True(t, path[1].Degenerate)
+	assert.Equal(t, Point{0, 2}, path[2].Point)
+	assert.Equal(t, 2.0, path[2].Value)
+}
+
+func request3(coefs []float64, vars []float64, signs ...string) models.SimplexRequest {
+	rows := len(vars) / 4
+	return models.SimplexRequest{
+		Objective:   models.Objective{N: 3, Coefficients: coefs},
+		Constraints: models.Constraints{Rows: rows, Cols: 4, Vars: vars, Signs: signs},
+	}
+}
+
+func TestSolve3(t *testing.T) {
+	// max 2 x1 + 3 x2 + x3 s.a. x1 + x2 + x3 <= 4, x1 + 3 x2 <= 6, x3 <= 3
+	g, err := Solve(request3([]float64{2, 3, 1}, []float64{1, 1, 1, 4, 1, 3, 0, 6, 0, 0, 1, 3}))
+	assert.NoError(t, err)
+	s := g.Solid
+	if !assert.NotNil(t, s) {
+		return
+	}
+	assert.Equal(t, "optimal", g.Status)
+	assert.True(t, g.Bounded)
+	assert.Equal(t, [3]float64{10, 5, 5}, s.Window)
+	assert.Equal(t, [3]string{"x1", "x2", "x3"}, s.Names)
+	assert.Len(t, s.Planes, 3)
+
+	pts := make([]Point3, len(s.Vertices))
+	for i, v := range s.Vertices {
+		pts[i] = v.Point3
+	}
+	assert.ElementsMatch(t, []Point3{
+		{0, 0, 0}, {4, 0, 0}, {3, 1, 0}, {0, 2, 0},
+		{0, 0, 3}, {1, 0, 3}, {0, 1, 3}, {0, 2, 2},
+	}, pts)
+	// Un poliedro cerrado cumple V - A + C = 2
+	assert.Len(t, s.Edges, 12)
+	assert.Len(t, s.Faces, 6)
+	if assert.NotNil(t, s.Optimum) {
+		assert.Equal(t, Point3{3, 1, 0}, s.Optimum.Point3)
+		assert.Equal(t, 9.0, s.Optimum.Value)
+		assert.Equal(t, []int{-3, 0, 1}, s.Optimum.Rows)
+	}
+	assert.False(t, g.Alternative)
+	// La cara de x1 + 3 x2 <= 6 es un triángulo
+	k := slices.IndexFunc(s.Faces, func(f Face) bool { return f.Row == 1 })
+	if assert.GreaterOrEqual(t, k, 0) {
+		assert.Equal(t, "R2", s.Faces[k].Name)
+		assert.ElementsMatch(t, []Point3{{3, 1, 0}, {0, 2, 0}, {0, 2, 2}}, s.Faces[k].Points)
+	}
+}
+
+func TestSolve3Cases(t *testing.T) {
+	// x1 + x2 + x3 >= 2: al minimizar x1 + 2 x2 + x3 hay dos vértices óptimos
+	req := request3([]float64{1, 2, 1}, []float64{1, 1, 1, 2}, ">=")
+	req.Objective.Type = "minimize"
+	g, err := Solve(req)
+	assert.NoError(t, err)
+	assert.False(t, g.Bounded)
+	assert.Equal(t, "optimal", g.Status)
+	assert.True(t, g.Alternative)
+	assert.Equal(t, 2.0, g.Solid.Optimum.Value)
+	// El poliedro recortado es la caja sin la esquina del origen
+	assert.Len(t, g.Solid.Edges, 15)
+
+	// Maximizar la misma región: no acotado
+	req.Objective.Type = "maximize"
+	g, _ = Solve(req)
+	assert.Equal(t, "unbounded", g.Status)
+	assert.Nil(t, g.Solid.Optimum)
+
+	// x1 + x2 + x3 <= 2 y x1 + x2 + x3 >= 5: infactible
+	g, _ = Solve(request3([]float64{1, 1, 1}, []float64{1, 1, 1, 2, 1, 1, 1, 5}, "<=", ">="))
+	assert.Equal(t, "infeasible", g.Status)
+	assert.Empty(t, g.Solid.Vertices)
+	assert.Empty(t, g.Solid.Faces)
+
+	// Con una igualdad el poliedro es plano: una sola cara
+	g, _ = Solve(request3([]float64{1, 2, 1}, []float64{1, 1, 1, 3, 1, 0, 0, 1}, "=", "<="))
+	assert.Equal(t, "optimal", g.Status)
+	assert.Len(t, g.Solid.Vertices, 4)
+	assert.Len(t, g.Solid.Faces, 1)
+	assert.Equal(t, Point3{0, 3, 0}, g.Solid.Optimum.Point3)
+}
+
+func TestTrace3(t *testing.T) {
+	req := request3([]float64{2, 3, 1}, []float64{1, 1, 1, 4, 1, 3, 0, 6, 0, 0, 1, 3})
+	res := simplex.SolveProblem(simplex.FromRequest(req))
+	g, err := Trace(req, res)
+	assert.NoError(t, err)
+
+	pts := make([][]float64, len(g.Path))
+	for i, p := range g.Path {
+		pts[i] = p.Values
+	}
+	// Cada tabla cae en un vértice del poliedro y el último es el óptimo
+	assert.Equal(t, [][]float64{{0, 0, 0}, {0, 2, 0}, {3, 1, 0}}, pts)
+	assert.Equal(t, 9.0, g.Path[2].Value)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSVG(&buf, g))
+	// Una cara por polígono más una punta de flecha por pivote
+	assert.Equal(t, len(g.Solid.Faces)+2, strings.Count(buf.String(), `<polygon points="`))
+	assert.Contains(t, buf.String(), "(3, 1, 0)  Z* = 9")
+
+	data, err := PNG(g)
+	assert.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(data))
+	assert.NoError(t, err)
+	// El óptimo está pintado de rojo
+	p := view{g.Solid.Window}.at(g.Solid.Optimum.Point3)
+	r, gr, b, _ := img.At(int(p.X*2), int(p.Y*2)).RGBA()
+	assert.Equal(t, [3]uint32{0xdcdc, 0x2626, 0x2626}, [3]uint32{r, gr, b})
+}
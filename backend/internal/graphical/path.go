@@ -0,0 +1,79 @@
+package graphical
+
+import (
+	"autosimplex/internal/simplex"
+)
+
+// PathPoint es la solución básica de una tabla del simplex, ubicada en el plano.
+type PathPoint struct {
+	Iteration int `json:"iteration"`
+	// Point son los valores de x1 y x2.
+	Point
+	// Values son los valores de todas las variables de decisión, por si el
+	// problema tiene más de dos.
+	Values []float64 `json:"values"`
+	Value  float64   `json:"value"`
+	// Feasible es false mientras haya variables artificiales positivas en la
+	// base: el punto queda fuera de la región factible.
+	Feasible bool `json:"feasible"`
+	// Degenerate indica que el pivote no movió el punto (paso degenerado).
+	Degenerate bool `json:"degenerate,omitempty"`
+}
+
+// Path devuelve la solución básica de cada tabla de res: las variables de
+// decisión básicas toman su valor de BVector y las no básicas valen 0, así que
+// cada tabla cae en un vértice (o, con artificiales en la base, en un punto
+// fuera de la región). c es el vector objetivo con el que se valora cada punto.
+func Path(res simplex.Result, c []float64) []PathPoint {
+	n := 0
+	kinds := map[int]simplex.VariableKind{}
+	for _, v := range res.Variables {
+		kinds[v.Index] = v.Kind
+		if v.Kind == simplex.Decision {
+			n++
+		}
+	}
+	out := make([]PathPoint, 0, len(res.Steps))
+	for _, st := range res.Steps {
+		p := PathPoint{Iteration: st.Iteration, Values: make([]float64, n), Feasible: true}
+		for i, col := range st.BaseVariables {
+			if i >= len(st.BVector) {
+				break
+			}
+			switch kinds[col] {
+			case simplex.Decision:
+				p.Values[col-1] = clean(st.BVector[i])
+			case simplex.Artificial:
+				if st.BVector[i] > 1e-9 {
+					p.Feasible = false
+				}
+			}
+		}
+		for j, v := range p.Values {
+			if j < len(c) {
+				p.Value += c[j] * v
+			}
+		}
+		p.Value = clean(p.Value)
+		if n >= 1 {
+			p.X = p.Values[0]
+		}
+		if n >= 2 {
+			p.Y = p.Values[1]
+		}
+		if len(out) > 0 && sameValues(out[len(out)-1].Values, p.Values) {
+			p.Degenerate = true
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func sameValues(a, b []float64) bool {
+	for j := range a {
+		if !near(Point{a[j], 0}, Point{b[j], 0}) {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
@@ -0,0 +1,174 @@
+package graphical
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"sort"
+	"strings"
+)
+
+// rasterCanvas dibuja sobre una imagen en memoria, para incrustarla en el PDF
+// (la biblioteca de PDF solo acepta imágenes PNG o JPG). Sin fuentes
+// disponibles, el texto se limita a los números de los ejes y las etiquetas,
+// con una fuente de mapa de bits de 3x5.
+type rasterCanvas struct {
+	img *image.RGBA
+	// scale multiplica las coordenadas para obtener una imagen más nítida
+	scale float64
+}
+
+// blend mezcla c sobre el píxel (x, y) según su transparencia y la cobertura.
+func (r *rasterCanvas) blend(x, y int, c color.RGBA, cover float64) {
+	if !(image.Point{x, y}.In(r.img.Rect)) || cover <= 0 {
+		return
+	}
+	a := float64(c.A) / 255 * math.Min(cover, 1)
+	dst := r.img.RGBAAt(x, y)
+	mix := func(s, d uint8) uint8 { return uint8(math.Round(float64(s)*a + float64(d)*(1-a))) }
+	r.img.SetRGBA(x, y, color.RGBA{mix(c.R, dst.R), mix(c.G, dst.G), mix(c.B, dst.B), 255})
+}
+
+// polygon rellena por barrido horizontal con la regla par-impar.
+func (r *rasterCanvas) polygon(pts []pixel, fill color.RGBA) {
+	b := r.img.Rect
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		cy := (float64(y) + 0.5) / r.scale
+		var xs []float64
+		for i := range pts {
+			p, q := pts[i], pts[(i+1)%len(pts)]
+			if (p.Y <= cy) == (q.Y <= cy) {
+				continue
+			}
+			xs = append(xs, p.X+(cy-p.Y)/(q.Y-p.Y)*(q.X-p.X))
+		}
+		sort.Float64s(xs)
+		for k := 0; k+1 < len(xs); k += 2 {
+			from := int(math.Ceil(xs[k]*r.scale - 0.5))
+			to := int(math.Floor(xs[k+1]*r.scale - 0.5))
+			for x := from; x <= to; x++ {
+				r.blend(x, y, fill, 1)
+			}
+		}
+	}
+}
+
+// line dibuja el segmento con el grosor dado; la cobertura de cada píxel
+// depende de su distancia al segmento, lo que suaviza los bordes.
+func (r *rasterCanvas) line(a, b pixel, c color.RGBA, w float64, dashed bool) {
+	ax, ay, bx, by := a.X*r.scale, a.Y*r.scale, b.X*r.scale, b.Y*r.scale
+	half := w * r.scale / 2
+	dx, dy := bx-ax, by-ay
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	dash, gap := 6*r.scale, 4*r.scale
+	minX, maxX := int(math.Floor(math.Min(ax, bx)-half-1)), int(math.Ceil(math.Max(ax, bx)+half+1))
+	minY, maxY := int(math.Floor(math.Min(ay, by)-half-1)), int(math.Ceil(math.Max(ay, by)+half+1))
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			t := ((px-ax)*dx + (py-ay)*dy) / (length * length)
+			if t < 0 || t > 1 {
+				continue
+			}
+			if dashed && math.Mod(t*length, dash+gap) > dash {
+				continue
+			}
+			d := math.Abs((px-ax)*dy-(py-ay)*dx) / length
+			r.blend(x, y, c, half+0.5-d)
+		}
+	}
+}
+
+func (r *rasterCanvas) circle(p pixel, radius float64, fill color.RGBA) {
+	cx, cy, rad := p.X*r.scale, p.Y*r.scale, radius*r.scale
+	for y := int(cy - rad - 1); y <= int(cy+rad+1); y++ {
+		for x := int(cx - rad - 1); x <= int(cx+rad+1); x++ {
+			d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			r.blend(x, y, fill, rad+0.5-d)
+		}
+	}
+}
+
+// glyphs es una fuente de 3x5 con los caracteres de números y etiquetas.
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", ".##", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'.': {"...", "...", "...", "...", ".#."},
+	',': {"...", "...", "...", ".#.", "#.."},
+	'-': {"...", "...", "###", "...", "..."},
+	'=': {"...", "###", "...", "###", "..."},
+	'(': {".#.", "#..", "#..", "#..", ".#."},
+	')': {".#.", "..#", "..#", "..#", ".#."},
+	'*': {"#.#", ".#.", "#.#", "...", "..."},
+	'Z': {"###", "..#", ".#.", "#..", "###"},
+	'x': {"...", "#.#", ".#.", "#.#", "..."},
+	' ': {"...", "...", "...", "...", "..."},
+}
+
+// text escribe s con la fuente de mapa de bits; si tiene caracteres que la
+// fuente no conoce (nombres de restricciones, por ejemplo) no escribe nada.
+func (r *rasterCanvas) text(p pixel, s string, c color.RGBA, anchor string) {
+	if !supported(s) {
+		return
+	}
+	const dot = 2.0 // lado de cada punto del glifo, en unidades del gráfico
+	n := len([]rune(s))
+	w := float64(4*n-1) * dot
+	x0 := p.X
+	switch anchor {
+	case "middle":
+		x0 -= w / 2
+	case "end":
+		x0 -= w
+	}
+	y0 := p.Y - 5*dot
+	for k, ch := range []rune(s) {
+		g := glyphs[ch]
+		for row, bits := range g {
+			for col, bit := range bits {
+				if bit != '#' {
+					continue
+				}
+				x := (x0 + float64(4*k+col)*dot) * r.scale
+				y := (y0 + float64(row)*dot) * r.scale
+				for yy := int(y); yy < int(y+dot*r.scale); yy++ {
+					for xx := int(x); xx < int(x+dot*r.scale); xx++ {
+						r.blend(xx, yy, c, 1)
+					}
+				}
+			}
+		}
+	}
+}
+
+// PNG dibuja la geometría como imagen PNG, al doble de resolución que el SVG.
+func PNG(g Geometry) ([]byte, error) {
+	cv := rasterCanvas{img: image.NewRGBA(image.Rect(0, 0, 2*width, 2*height)), scale: 2}
+	for i := range cv.img.Pix {
+		cv.img.Pix[i] = 255
+	}
+	draw(&cv, g)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, cv.img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// supported indica si la fuente de mapa de bits puede escribir s.
+func supported(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { _, ok := glyphs[r]; return !ok }) < 0
+}
@@ -0,0 +1,473 @@
+package graphical
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"autosimplex/internal/models"
+)
+
+// maxSolidRows limita las restricciones de un problema de tres variables: los
+// vértices se buscan entre todas las ternas de planos.
+const maxSolidRows = 100
+
+// Point3 es un punto del espacio (x1, x2, x3).
+type Point3 struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// Plane es el plano a·x = b que limita una restricción. Una fila de rango aporta
+// dos planos.
+type Plane struct {
+	Row  int        `json:"row"`
+	Name string     `json:"name"`
+	A    [3]float64 `json:"a"`
+	B    float64    `json:"b"`
+	// Sign es "<=", ">=" o "=": el semiespacio factible respecto del plano.
+	Sign string `json:"sign"`
+}
+
+// Vertex3 es un vértice del poliedro factible con el valor del objetivo.
+type Vertex3 struct {
+	Point3
+	Value float64 `json:"value"`
+	// Rows son las restricciones activas en el vértice; -1, -2 y -3 son los
+	// planos x1 = 0, x2 = 0 y x3 = 0.
+	Rows []int `json:"rows"`
+}
+
+// Face es una cara del poliedro recortado a la ventana, sobre el plano de una
+// restricción (o de un eje, con Row negativo como en Vertex3).
+type Face struct {
+	Row  int    `json:"row"`
+	Name string `json:"name"`
+	// Points son los vértices de la cara en orden, como un polígono.
+	Points []Point3 `json:"points"`
+}
+
+// Edge es una arista del poliedro recortado a la ventana.
+type Edge struct {
+	From Point3 `json:"from"`
+	To   Point3 `json:"to"`
+}
+
+// Solid es el método gráfico de un problema con tres variables: el poliedro
+// factible, recortado a la caja [0, Window], se dibuja en perspectiva
+// isométrica con el recorrido del simplex encima.
+type Solid struct {
+	Names     [3]string  `json:"names"`
+	Objective [3]float64 `json:"objective"`
+	Window    [3]float64 `json:"window"`
+	Planes    []Plane    `json:"planes"`
+	Faces     []Face     `json:"faces"`
+	Edges     []Edge     `json:"edges"`
+	// Vertices son los vértices verdaderos del poliedro (no los cortes con la ventana).
+	Vertices []Vertex3 `json:"vertices"`
+	// Optimum es el vértice óptimo, si existe.
+	Optimum *Vertex3 `json:"optimum,omitempty"`
+}
+
+// halfSpace es a·x <= b, con la restricción que lo originó. Las filas -4, -5 y
+// -6 son las paredes de la ventana.
+type halfSpace struct {
+	a   [3]float64
+	b   float64
+	row int
+}
+
+// corner es un vértice de un poliedro con los semiespacios (por posición) que
+// están activos en él.
+type corner struct {
+	p      Point3
+	active []int
+}
+
+// solve3 es solve para tres variables; extra son puntos que la ventana debe
+// contener. Los campos del plano de la geometría quedan vacíos.
+func solve3(req models.SimplexRequest, extra []Point3) (Geometry, error) {
+	cons := req.Constraints
+	if len(cons.Vars) != cons.Rows*cons.Cols || cons.Cols != 4 {
+		return Geometry{}, fmt.Errorf("la matriz de restricciones debe tener 4 columnas por fila")
+	}
+	if cons.Rows > maxSolidRows {
+		return Geometry{}, fmt.Errorf("el método gráfico en tres dimensiones admite hasta %d restricciones", maxSolidRows)
+	}
+	s := &Solid{Planes: []Plane{}, Faces: []Face{}, Edges: []Edge{}, Vertices: []Vertex3{}}
+	g := Geometry{
+		Minimize: strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize"),
+		Lines:    []Line{},
+		Region:   []Point{},
+		Vertices: []Vertex{},
+		IsoLines: []IsoLine{},
+		Solid:    s,
+	}
+	for j := range 3 {
+		s.Objective[j] = req.Objective.Coefficients[j]
+		s.Names[j] = fmt.Sprintf("x%d", j+1)
+		if j < len(req.Objective.Names) && req.Objective.Names[j] != "" {
+			s.Names[j] = req.Objective.Names[j]
+		}
+	}
+
+	// Semiespacios a·x <= b; xj >= 0 lleva la fila -j
+	spaces := []halfSpace{{a: [3]float64{-1, 0, 0}, row: -1}, {a: [3]float64{0, -1, 0}, row: -2}, {a: [3]float64{0, 0, -1}, row: -3}}
+	for i := range cons.Rows {
+		a := [3]float64{cons.Vars[i*4], cons.Vars[i*4+1], cons.Vars[i*4+2]}
+		b := cons.Vars[i*4+3]
+		name := fmt.Sprintf("R%d", i+1)
+		if i < len(cons.Names) && cons.Names[i] != "" {
+			name = cons.Names[i]
+		}
+		sign := "<="
+		if i < len(cons.Signs) {
+			sign = cons.Signs[i]
+		}
+		neg := [3]float64{-a[0], -a[1], -a[2]}
+		switch sign {
+		case ">=":
+			spaces = append(spaces, halfSpace{a: neg, b: -b, row: i})
+		case "=":
+			spaces = append(spaces, halfSpace{a: a, b: b, row: i}, halfSpace{a: neg, b: -b, row: i})
+		case "range":
+			lo := 0.0
+			if i < len(cons.Lower) {
+				lo = cons.Lower[i]
+			}
+			spaces = append(spaces, halfSpace{a: a, b: b, row: i}, halfSpace{a: neg, b: -lo, row: i})
+			s.Planes = append(s.Planes, Plane{Row: i, Name: name, A: a, B: lo, Sign: ">="})
+			sign = "<="
+		default:
+			spaces = append(spaces, halfSpace{a: a, b: b, row: i})
+			sign = "<="
+		}
+		s.Planes = append(s.Planes, Plane{Row: i, Name: name, A: a, B: b, Sign: sign})
+	}
+
+	for _, c := range corners(spaces) {
+		s.Vertices = append(s.Vertices, Vertex3{Point3: c.p, Value: dot(s.Objective, c.p), Rows: activeRows(spaces, c.active)})
+	}
+	s.Window = window3(s.Vertices, s.Planes, extra)
+
+	// El poliedro que se dibuja es el recortado a la ventana
+	clipped := slices.Clone(spaces)
+	for j := range 3 {
+		var a [3]float64
+		a[j] = 1
+		clipped = append(clipped, halfSpace{a: a, b: s.Window[j], row: -4 - j})
+	}
+	cs := corners(clipped)
+	s.Faces = faces(clipped, cs, s)
+	s.Edges = edges(clipped, cs)
+	// Si el poliedro es acotado, la ventana (con margen) lo contiene entero; si
+	// toca una pared es porque sigue indefinidamente
+	g.Bounded = len(cs) > 0
+	for _, c := range cs {
+		for _, k := range c.active {
+			if clipped[k].row <= -4 {
+				g.Bounded = false
+			}
+		}
+	}
+
+	switch {
+	case len(s.Vertices) == 0:
+		g.Status = "infeasible"
+	case !g.Bounded && improvingRay3(spaces, s.Objective, g.Minimize):
+		g.Status = "unbounded"
+	default:
+		g.Status = "optimal"
+		best := 0
+		for k, v := range s.Vertices {
+			if better(v.Value, s.Vertices[best].Value, g.Minimize) {
+				best = k
+			}
+		}
+		opt := s.Vertices[best]
+		s.Optimum = &opt
+		for k, v := range s.Vertices {
+			if k != best && math.Abs(v.Value-opt.Value) <= eps*math.Max(1, math.Abs(opt.Value)) {
+				g.Alternative = true
+			}
+		}
+	}
+	return g, nil
+}
+
+func dot(a [3]float64, p Point3) float64 {
+	return clean(a[0]*p.X + a[1]*p.Y + a[2]*p.Z)
+}
+
+func cross(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func norm(a [3]float64) float64 {
+	return math.Sqrt(a[0]*a[0] + a[1]*a[1] + a[2]*a[2])
+}
+
+// tight indica si p está sobre el plano de h, con tolerancia relativa.
+func tight(h halfSpace, p Point3) bool {
+	lhs := h.a[0]*p.X + h.a[1]*p.Y + h.a[2]*p.Z
+	return math.Abs(lhs-h.b) <= 1e-7*math.Max(1, math.Abs(h.b))
+}
+
+// corners intersecta cada terna de planos y se queda con los puntos que cumplen
+// todos los semiespacios, sin repetir. Como el poliedro está en el primer
+// octante, si no es vacío tiene al menos un vértice.
+func corners(spaces []halfSpace) []corner {
+	var out []corner
+	for i := range spaces {
+		for j := i + 1; j < len(spaces); j++ {
+			for k := j + 1; k < len(spaces); k++ {
+				p, ok := intersect3(spaces[i], spaces[j], spaces[k])
+				if !ok {
+					continue
+				}
+				p = Point3{clean(p.X), clean(p.Y), clean(p.Z)}
+				if slices.ContainsFunc(out, func(c corner) bool { return near3(c.p, p) }) {
+					continue
+				}
+				c := corner{p: p}
+				inside := true
+				for h, sp := range spaces {
+					lhs := sp.a[0]*p.X + sp.a[1]*p.Y + sp.a[2]*p.Z
+					if lhs > sp.b+1e-7*math.Max(1, math.Abs(sp.b)) {
+						inside = false
+						break
+					}
+					if tight(sp, p) {
+						c.active = append(c.active, h)
+					}
+				}
+				if inside {
+					out = append(out, c)
+				}
+			}
+		}
+	}
+	// Orden estable, para que la salida no dependa del orden de las filas
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i].p, out[j].p
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.Z < b.Z
+	})
+	return out
+}
+
+// intersect3 resuelve el sistema de los tres planos por Cramer.
+func intersect3(p, q, r halfSpace) (Point3, bool) {
+	qr := cross(q.a, r.a)
+	det := p.a[0]*qr[0] + p.a[1]*qr[1] + p.a[2]*qr[2]
+	if math.Abs(det) < eps {
+		return Point3{}, false
+	}
+	rp, pq := cross(r.a, p.a), cross(p.a, q.a)
+	var x [3]float64
+	for j := range 3 {
+		x[j] = (p.b*qr[j] + q.b*rp[j] + r.b*pq[j]) / det
+	}
+	return Point3{x[0], x[1], x[2]}, true
+}
+
+func near3(p, q Point3) bool {
+	tol := func(v float64) float64 { return 1e-7 * math.Max(1, math.Abs(v)) }
+	return math.Abs(p.X-q.X) <= tol(p.X) && math.Abs(p.Y-q.Y) <= tol(p.Y) && math.Abs(p.Z-q.Z) <= tol(p.Z)
+}
+
+// activeRows devuelve las filas de los semiespacios activos, sin repetir.
+func activeRows(spaces []halfSpace, active []int) []int {
+	rows := []int{}
+	for _, k := range active {
+		if !slices.Contains(rows, spaces[k].row) {
+			rows = append(rows, spaces[k].row)
+		}
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// edges une los pares de vértices que comparten dos planos no paralelos: el
+// segmento entre ellos está sobre la recta en que se cortan esos planos.
+func edges(spaces []halfSpace, cs []corner) []Edge {
+	out := []Edge{}
+	for i := range cs {
+		for j := i + 1; j < len(cs); j++ {
+			var common []int
+			for _, k := range cs[i].active {
+				if slices.Contains(cs[j].active, k) {
+					common = append(common, k)
+				}
+			}
+			if independentPair(spaces, common) {
+				out = append(out, Edge{From: cs[i].p, To: cs[j].p})
+			}
+		}
+	}
+	return out
+}
+
+func independentPair(spaces []halfSpace, idx []int) bool {
+	for x := range idx {
+		for y := x + 1; y < len(idx); y++ {
+			if norm(cross(spaces[idx[x]].a, spaces[idx[y]].a)) > eps {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// faces arma un polígono por cada plano (de una restricción o de un eje) que
+// contiene al menos tres vértices no alineados del poliedro recortado. Los dos
+// planos de una igualdad dan la misma cara, que se cuenta una vez.
+func faces(spaces []halfSpace, cs []corner, s *Solid) []Face {
+	out := []Face{}
+	seen := map[string]bool{}
+	for k, sp := range spaces {
+		if sp.row <= -4 {
+			continue
+		}
+		var idx []int
+		for i, c := range cs {
+			if slices.Contains(c.active, k) {
+				idx = append(idx, i)
+			}
+		}
+		if len(idx) < 3 {
+			continue
+		}
+		key := fmt.Sprint(idx)
+		if seen[key] {
+			continue
+		}
+		pts := make([]Point3, len(idx))
+		for i, ci := range idx {
+			pts[i] = cs[ci].p
+		}
+		if !orderOnPlane(pts, sp.a) {
+			continue
+		}
+		seen[key] = true
+		name := ""
+		if sp.row >= 0 {
+			name = s.Planes[slices.IndexFunc(s.Planes, func(p Plane) bool { return p.Row == sp.row })].Name
+		} else {
+			name = s.Names[-sp.row-1] + " = 0"
+		}
+		out = append(out, Face{Row: sp.row, Name: name, Points: pts})
+	}
+	return out
+}
+
+// orderOnPlane ordena pts por ángulo alrededor de su centro, medido sobre el
+// plano de normal n. Devuelve false si los puntos están alineados.
+func orderOnPlane(pts []Point3, n [3]float64) bool {
+	var c [3]float64
+	for _, p := range pts {
+		c[0] += p.X / float64(len(pts))
+		c[1] += p.Y / float64(len(pts))
+		c[2] += p.Z / float64(len(pts))
+	}
+	// Una base (u, v) del plano
+	u := cross(n, [3]float64{1, 0, 0})
+	if norm(u) < 0.5*norm(n) {
+		u = cross(n, [3]float64{0, 1, 0})
+	}
+	v := cross(n, u)
+	angle := func(p Point3) float64 {
+		d := [3]float64{p.X - c[0], p.Y - c[1], p.Z - c[2]}
+		return math.Atan2(d[0]*v[0]+d[1]*v[1]+d[2]*v[2], d[0]*u[0]+d[1]*u[1]+d[2]*u[2])
+	}
+	sort.SliceStable(pts, func(i, j int) bool { return angle(pts[i]) < angle(pts[j]) })
+	// Alineados si todos los productos cruzados con el primer lado se anulan
+	for i := 2; i < len(pts); i++ {
+		a := [3]float64{pts[1].X - pts[0].X, pts[1].Y - pts[0].Y, pts[1].Z - pts[0].Z}
+		b := [3]float64{pts[i].X - pts[0].X, pts[i].Y - pts[0].Y, pts[i].Z - pts[0].Z}
+		if norm(cross(a, b)) > 1e-9*math.Max(1, norm(a)*norm(b)) {
+			return true
+		}
+	}
+	return false
+}
+
+// window3 elige una caja que contiene todos los vértices, los puntos extra y
+// los cortes de los planos con los ejes, con un 20 % de margen.
+func window3(vs []Vertex3, planes []Plane, extra []Point3) [3]float64 {
+	var m [3]float64
+	grow := func(p Point3) {
+		m = [3]float64{math.Max(m[0], p.X), math.Max(m[1], p.Y), math.Max(m[2], p.Z)}
+	}
+	for _, v := range vs {
+		grow(v.Point3)
+	}
+	for _, p := range extra {
+		grow(p)
+	}
+	for _, pl := range planes {
+		for j := range 3 {
+			if math.Abs(pl.A[j]) > eps {
+				if t := pl.B / pl.A[j]; t > 0 {
+					m[j] = math.Max(m[j], t)
+				}
+			}
+		}
+	}
+	top := math.Max(1, math.Max(m[0], math.Max(m[1], m[2])))
+	for j := range m {
+		if m[j] <= eps {
+			m[j] = top
+		}
+		m[j] = niceCeil(m[j] * 1.2)
+	}
+	return m
+}
+
+// improvingRay3 indica si el poliedro tiene una dirección de recesión d
+// (A·d <= 0, d >= 0) que mejora el objetivo. Los rayos extremos del cono están
+// sobre los ejes o sobre la recta en que se cortan dos de sus planos.
+func improvingRay3(spaces []halfSpace, c [3]float64, minimize bool) bool {
+	cands := [][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	for i := range spaces {
+		for j := i + 1; j < len(spaces); j++ {
+			d := cross(spaces[i].a, spaces[j].a)
+			cands = append(cands, d, [3]float64{-d[0], -d[1], -d[2]})
+		}
+	}
+	for _, d := range cands {
+		l := norm(d)
+		if l < eps {
+			continue
+		}
+		d = [3]float64{d[0] / l, d[1] / l, d[2] / l}
+		ok := true
+		for _, h := range spaces {
+			if h.a[0]*d[0]+h.a[1]*d[1]+h.a[2]*d[2] > eps {
+				ok = false
+				break
+			}
+		}
+		gain := c[0]*d[0] + c[1]*d[1] + c[2]*d[2]
+		if minimize {
+			gain = -gain
+		}
+		if ok && gain > eps {
+			return true
+		}
+	}
+	return false
+}
+
+// label escribe las coordenadas de un punto del espacio: (1, 2, 3).
+func (p Point3) label() string {
+	return "(" + strings.Join([]string{number(p.X), number(p.Y), number(p.Z)}, ", ") + ")"
+}
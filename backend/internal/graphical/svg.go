@@ -0,0 +1,77 @@
+package graphical
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// svgCanvas acumula los elementos del SVG.
+type svgCanvas struct {
+	b strings.Builder
+}
+
+func rgb(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func opacity(c color.RGBA) string {
+	if c.A == 255 {
+		return ""
+	}
+	return fmt.Sprintf(` fill-opacity="%.2f"`, float64(c.A)/255)
+}
+
+func (s *svgCanvas) polygon(pts []pixel, fill color.RGBA) {
+	coords := make([]string, len(pts))
+	for i, p := range pts {
+		coords[i] = fmt.Sprintf("%.2f,%.2f", p.X, p.Y)
+	}
+	fmt.Fprintf(&s.b, `<polygon points="%s" fill="%s"%s/>`+"\n", strings.Join(coords, " "), rgb(fill), opacity(fill))
+}
+
+func (s *svgCanvas) line(a, b pixel, c color.RGBA, width float64, dashed bool) {
+	dash := ""
+	if dashed {
+		dash = ` stroke-dasharray="6 4"`
+	}
+	fmt.Fprintf(&s.b, `<line x1="%.2f" y1="%.2f" x2="%.2f" y2="%.2f" stroke="%s" stroke-width="%g"%s/>`+"\n",
+		a.X, a.Y, b.X, b.Y, rgb(c), width, dash)
+}
+
+func (s *svgCanvas) circle(p pixel, r float64, fill color.RGBA) {
+	fmt.Fprintf(&s.b, `<circle cx="%.2f" cy="%.2f" r="%g" fill="%s"/>`+"\n", p.X, p.Y, r, rgb(fill))
+}
+
+func (s *svgCanvas) text(p pixel, txt string, c color.RGBA, anchor string) {
+	fmt.Fprintf(&s.b, `<text x="%.2f" y="%.2f" fill="%s" text-anchor="%s">%s</text>`+"\n",
+		p.X, p.Y, rgb(c), anchor, html.EscapeString(txt))
+}
+
+// WriteSVG dibuja la geometría como un SVG autocontenido.
+func WriteSVG(w io.Writer, g Geometry) error {
+	var cv svgCanvas
+	fmt.Fprintf(&cv.b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&cv.b, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", width, height)
+	draw(&cv, g)
+	cv.b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, cv.b.String())
+	return err
+}
+
+// Write es el formato svg de /process: resuelve req por el método gráfico y lo
+// dibuja con el recorrido del simplex de res. Devuelve ErrDimension si req no
+// tiene dos o tres variables.
+func Write(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+	g, err := Trace(req, res)
+	if err != nil {
+		return err
+	}
+	return WriteSVG(w, g)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"autosimplex/internal/bases"
+	"autosimplex/internal/simplex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bases enumera todas las soluciones básicas de un problema chico y devuelve la
+// tabla completa (cada base con su clasificación y su solución) junto con la
+// mejor base factible. Los problemas con más de bases.MaxBases combinaciones se
+// rechazan con 422.
+func Bases() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		req, ok := bindRequest(c)
+		if !ok {
+			return
+		}
+		t, err := bases.Enumerate(simplex.FromRequest(req))
+		if errors.Is(err, bases.ErrTooLarge) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, t)
+	}
+}
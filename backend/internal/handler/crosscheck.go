@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"autosimplex/internal/crosscheck"
+	"autosimplex/internal/models"
+	"autosimplex/internal/render"
+	"autosimplex/internal/simplex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// crossCheckRequested lee ?crosscheck=; ok es false si el valor no es booleano.
+func crossCheckRequested(c *gin.Context) (check, ok bool) {
+	v := c.Query("crosscheck")
+	if v == "" {
+		return false, true
+	}
+	check, err := strconv.ParseBool(v)
+	return check, err == nil
+}
+
+// crossCheck resuelve p también con gonum y deja el veredicto en el encabezado
+// X-Crosscheck (agree o disagree) para cualquier formato. Si los solvers no
+// coinciden lo agrega al aviso de res; con el formato json el informe completo
+// va en el campo "crosscheck" de la respuesta.
+func crossCheck(c *gin.Context, out render.Renderer, p simplex.Problem, res *simplex.Result) render.Renderer {
+	report := crosscheck.Check(p, *res)
+	if report.Agree {
+		c.Header("X-Crosscheck", "agree")
+	} else {
+		c.Header("X-Crosscheck", "disagree")
+		addWarning(res, "La verificación con "+crosscheck.Solver+" no coincide: "+report.Message)
+	}
+	if out.Name == "json" {
+		out.Write = func(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+			body := render.Response(req, res)
+			body["crosscheck"] = report
+			return json.NewEncoder(w).Encode(body)
+		}
+	}
+	return out
+}
+
+// addWarning agrega note al aviso de res, separada por un punto.
+func addWarning(res *simplex.Result, note string) {
+	if res.Warning != "" {
+		res.Warning += ". " + note
+	} else {
+		res.Warning = note
+	}
+}
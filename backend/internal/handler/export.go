@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"autosimplex/internal/codegen"
+	"autosimplex/internal/lpfile"
+	"autosimplex/internal/models"
+	"autosimplex/internal/mps"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exporter escribe una solicitud en otro formato de modelo.
+type exporter struct {
+	contentType string
+	filename    string
+	write       func(w io.Writer, req models.SimplexRequest) error
+}
+
+var exporters = map[string]exporter{
+	"lp":  {contentType: "text/plain; charset=utf-8", filename: "modelo.lp", write: lpfile.Write},
+	"mps": {contentType: "text/plain; charset=utf-8", filename: "modelo.mps", write: mps.Write},
+	// Código para otras herramientas, cuando el modelo se vuelve grande
+	"go":   {contentType: "text/x-go; charset=utf-8", filename: "main.go", write: codegen.WriteGo},
+	"pulp": {contentType: "text/x-python; charset=utf-8", filename: "modelo.py", write: codegen.WritePuLP},
+	"ampl": {contentType: "application/zip", filename: "modelo_ampl.zip", write: codegen.WriteAMPL},
+}
+
+// Export convierte el modelo recibido (en cualquier modo de entrada de /process)
+// al formato pedido en ?format=, sin resolverlo.
+func Export() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		req, ok := bindRequest(c)
+		if !ok {
+			return
+		}
+
+		exp, ok := exporters[c.Query("format")]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Formato de exportación no soportado"})
+			return
+		}
+		var buf bytes.Buffer
+		if err := exp.write(&buf, req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename="+exp.filename)
+		c.Data(http.StatusOK, exp.contentType, buf.Bytes())
+	}
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"autosimplex/internal/graphical"
+	"autosimplex/internal/simplex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Graphical resuelve por el método gráfico un problema de dos variables y
+// devuelve la geometría (rectas, región, vértices, curvas de nivel, óptimo y el
+// recorrido del simplex) en JSON; con tres variables, el poliedro factible en
+// "solid". El mismo gráfico se obtiene en SVG con POST /process?format=svg.
+func Graphical() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		req, ok := bindRequest(c)
+		if !ok {
+			return
+		}
+		g, err := graphical.Trace(req, simplex.SolveProblem(simplex.FromRequest(req)))
+		if errors.Is(err, graphical.ErrDimension) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, g)
+	}
+}
@@ -1,88 +1,74 @@
 package handler
 
 import (
+	"autosimplex/internal/graphical"
 	"autosimplex/internal/models"
-	"autosimplex/internal/pdf"
+	"autosimplex/internal/render"
 	"autosimplex/internal/simplex"
+	"bytes"
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"gonum.org/v1/gonum/mat"
 )
 
 func Process() func(c *gin.Context) {
 	return func(c *gin.Context) {
-		var req models.SimplexRequest
-		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": err.Error()})
+		// Elegir el formato de salida antes de resolver: ?format= tiene prioridad
+		// sobre el encabezado Accept
+		c.Header("Vary", "Accept")
+		out, ok := render.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if !ok {
+			c.JSON(http.StatusNotAcceptable, gin.H{
+				"error": "Formato de salida no soportado. Formatos disponibles: " + strings.Join(render.Names(), ", "),
+			})
 			return
 		}
 
-		// Función objetivo
-		n := req.Objective.N
-		coefs := req.Objective.Coefficients
-		if validateReqObjective(c, n, coefs, req.Objective.Type) {
+		// ?crosscheck=true resuelve también con gonum y compara los resultados
+		check, ok := crossCheckRequested(c)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "El parámetro crosscheck debe ser true o false"})
 			return
 		}
-		// Construir vector objetivo. Si la solicitud pide minimizar, convertir
-		// el problema en una maximización negando los coeficientes.
-		objective := mat.NewVecDense(n, coefs)
-		isMinimize := strings.ToLower(strings.TrimSpace(req.Objective.Type)) == "minimize"
-		var maximizeVec *mat.VecDense
-		if isMinimize {
-			neg := make([]float64, n)
-			for i := range n {
-				neg[i] = -coefs[i]
-			}
-			maximizeVec = mat.NewVecDense(n, neg)
-		} else {
-			maximizeVec = objective
-		}
 
-		// Matriz de restricciones (incluye lado derecho)
-		rows := req.Constraints.Rows
-		cols := req.Constraints.Cols
-		vars := req.Constraints.Vars
-		if validateReqConstraints(c, rows, cols, vars) {
+		req, ok := bindRequest(c)
+		if !ok {
 			return
 		}
-		constraintMatrix := mat.NewDense(rows, cols, vars)
-
-		// Construir slice de signos: usar signos proporcionados si existen, sino usar "<=" por defecto
-		signs := req.Constraints.Signs
-		if len(signs) == 0 {
-			signs = make([]string, rows)
-			for i := range rows {
-				signs[i] = "<="
-			}
-		}
 
-		result, solution, steps, warning := simplex.SolveWithSigns(maximizeVec, constraintMatrix, signs)
+		p := simplex.FromRequest(req)
+		res := simplex.SolveProblem(p)
 
-		// Si fue una solicitud de minimización, invertir el valor óptimo retornado
-		// porque resolvimos la maximización equivalente de -c.
-		if isMinimize {
-			result = -result
+		// El solver resuelve la relajación lineal: avisar si se declararon enteras
+		if len(req.Integers) > 0 {
+			addWarning(&res, "Las variables enteras declaradas no se imponen: se resolvió la relajación lineal")
+		}
+		if check {
+			out = crossCheck(c, out, p, &res)
 		}
 
-		// Si se solicita formato PDF, generar y devolver PDF
-		format := c.Query("format")
-		if format == "pdf" {
-			c.Writer.Header().Set("Content-Type", "application/pdf")
-			c.Writer.Header().Set("Content-Disposition", "attachment; filename=resultado_simplex.pdf")
-			if err := pdf.GenerateSimplexPDF(result, solution, steps, c.Writer); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-			}
+		sendReport(c, out, req, res)
+	}
+}
+
+// sendReport genera la salida con el formato elegido en memoria, para poder
+// responder con un error si falla, y la envía con su Content-Type y, si
+// corresponde, su Content-Disposition.
+func sendReport(c *gin.Context, out render.Renderer, req models.SimplexRequest, res simplex.Result) {
+	var buf bytes.Buffer
+	if err := out.Write(&buf, req, res); err != nil {
+		// El formato svg solo se aplica a problemas de dos o tres variables
+		if errors.Is(err, graphical.ErrDimension) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 			return
 		}
-
-		c.JSON(http.StatusOK, gin.H{
-			"optimal_value": result,
-			"solution":      solution,
-			"steps":         steps,
-			"warning":       warning,
-		})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if d := out.Disposition(); d != "" {
+		c.Header("Content-Disposition", d)
 	}
+	c.Data(http.StatusOK, out.ContentType, buf.Bytes())
 }
@@ -3,10 +3,13 @@ package handler
 import (
 	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"autosimplex/internal/schema"
 	"autosimplex/internal/simplex"
 
 	"github.com/gin-gonic/gin"
@@ -27,8 +30,8 @@ func TestProcess_ValidRequest(t *testing.T) {
 		},
 		"constraints": map[string]any{
 			"rows": 2,
-			"cols": 2,
-			"vars": []float64{1, 2, 3, 4},
+			"cols": 3,
+			"vars": []float64{1, 2, 3, 4, 5, 6},
 		},
 	})
 	req, _ := http.NewRequest(http.MethodPost, "/process", bytes.NewBuffer(body))
@@ -45,6 +48,12 @@ func TestProcess_ValidRequest(t *testing.T) {
 	assert.Contains(t, resp, "solution")
 }
 
+// validationResponse es el cuerpo de un 400 por una solicitud inválida.
+type validationResponse struct {
+	Error  string         `json:"error"`
+	Errors []schema.Issue `json:"errors"`
+}
+
 func TestProcess_InvalidConstraints(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -58,8 +67,8 @@ func TestProcess_InvalidConstraints(t *testing.T) {
 		},
 		"constraints": map[string]any{
 			"rows": 2,
-			"cols": 2,
-			"vars": []float64{1, 2, 3}, // debería ser 4
+			"cols": 3,
+			"vars": []float64{1, 2, 3, 4, 5}, // debería ser 6
 		},
 	})
 	req, _ := http.NewRequest(http.MethodPost, "/process", bytes.NewBuffer(body))
@@ -69,10 +78,13 @@ func TestProcess_InvalidConstraints(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var resp map[string]string
+	var resp validationResponse
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	assert.Contains(t, resp["error"], "cantidad de variables")
+	assert.Contains(t, resp.Error, "cantidad de variables")
+	assert.Equal(t, []schema.Issue{{
+		Path: "/constraints/vars", Code: "length_mismatch", Message: resp.Error,
+	}}, resp.Errors)
 }
 
 func TestProcess_InvalidObjective(t *testing.T) {
@@ -87,8 +99,8 @@ func TestProcess_InvalidObjective(t *testing.T) {
 		},
 		"constraints": map[string]any{
 			"rows": 1,
-			"cols": 1,
-			"vars": []float64{1},
+			"cols": 3,
+			"vars": []float64{1, 1, 1},
 		},
 	})
 	req, _ := http.NewRequest(http.MethodPost, "/process", bytes.NewBuffer(body))
@@ -98,10 +110,13 @@ func TestProcess_InvalidObjective(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var resp map[string]string
+	var resp validationResponse
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	assert.Contains(t, resp["error"], "coeficientes")
+	assert.Contains(t, resp.Error, "coeficientes")
+	if assert.Len(t, resp.Errors, 1) {
+		assert.Equal(t, "/objective/coefficients", resp.Errors[0].Path)
+	}
 }
 
 func TestProcess_InvalidJSON(t *testing.T) {
@@ -118,16 +133,18 @@ func TestProcess_InvalidJSON(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var resp map[string]string
+	var resp validationResponse
 	err := json.Unmarshal(w.Body.Bytes(), &resp)
 	assert.NoError(t, err)
-	_, ok := resp["error"]
-	assert.True(t, ok)
+	assert.NotEmpty(t, resp.Error)
+	assert.Equal(t, []schema.Issue{{
+		Path: "/objective/coefficients/1", Code: "type", Message: resp.Error,
+	}}, resp.Errors)
 }
 
 func TestProcess_MinimizeHandlerMatchesManualConversion(t *testing.T) {
-	// Ensure that handler's minimize flow (negating coefficients + inverting result)
-	// matches the manual conversion using the simplex solver.
+	// Ensure that handler's native minimize flow matches the manual conversion
+	// (negating coefficients + inverting result) using the simplex solver.
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.POST("/process", Process())
@@ -222,3 +239,921 @@ func TestProcess_MinimizeWithGreaterEqual(t *testing.T) {
 	assert.True(t, ok)
 	assert.Equal(t, expectedMin, val)
 }
+
+func TestProcess_RangeConstraint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	// Maximizar x1 + x2 con 2 <= x1 + x2 <= 5 en una sola fila
+	body, _ := json.Marshal(map[string]any{
+		"objective": map[string]any{
+			"n":            2,
+			"coefficients": []float64{1, 1},
+		},
+		"constraints": map[string]any{
+			"rows":  1,
+			"cols":  3,
+			"vars":  []float64{1, 1, 5},
+			"signs": []string{"range"},
+			"lower": []float64{2},
+		},
+	})
+	req, _ := http.NewRequest(http.MethodPost, "/process", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5.0, resp["optimal_value"], 1e-9)
+
+	// Una cota inferior mayor que la superior se rechaza
+	body, _ = json.Marshal(map[string]any{
+		"objective": map[string]any{
+			"n":            2,
+			"coefficients": []float64{1, 1},
+		},
+		"constraints": map[string]any{
+			"rows":  1,
+			"cols":  3,
+			"vars":  []float64{1, 1, 5},
+			"signs": []string{"range"},
+			"lower": []float64{6},
+		},
+	})
+	req, _ = http.NewRequest(http.MethodPost, "/process", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestProcess_TextInput(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := "max 3x + 2y\ns.t.\nx + y <= 4\nx + 3y <= 6\nx <= 3\n"
+	req, _ := http.NewRequest(http.MethodPost, "/process", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "text/plain")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 11.0, resp["optimal_value"], 1e-9)
+
+	// Los errores de sintaxis indican línea y columna
+	req, _ = http.NewRequest(http.MethodPost, "/process?input=text", bytes.NewBufferString("max 3x + 2y\nx + y 4\n"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, resp["line"])
+	assert.Equal(t, 7.0, resp["column"])
+}
+
+func TestProcess_LPFileUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+	router.POST("/export", Export())
+
+	lp := "Maximize\n obj: 3 x + 2 y\nSubject To\n c1: x + y <= 4\n c2: x + 3 y <= 6\nBounds\n x <= 3\nEnd\n"
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "modelo.lp")
+	_, _ = fw.Write([]byte(lp))
+	_ = mw.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/process", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 11.0, resp["optimal_value"], 1e-9)
+
+	// Exportar a LP una solicitud JSON
+	jsonBody, _ := json.Marshal(map[string]any{
+		"objective": map[string]any{
+			"n":            2,
+			"coefficients": []float64{3, 2},
+			"type":         "minimize",
+		},
+		"constraints": map[string]any{
+			"rows":  1,
+			"cols":  3,
+			"vars":  []float64{1, 1, 4},
+			"signs": []string{">="},
+		},
+	})
+	req, _ = http.NewRequest(http.MethodPost, "/export?format=lp", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "modelo.lp")
+	assert.Contains(t, w.Body.String(), "Minimize\n obj: 3 x1 + 2 x2")
+	assert.Contains(t, w.Body.String(), "c1: x1 + x2 >= 4")
+}
+
+func TestProcess_MPSFileUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+	router.POST("/export", Export())
+
+	src := "NAME ejemplo\nOBJSENSE MAX\nROWS\n N obj\n L c1\n L c2\nCOLUMNS\n x obj 3 c1 1\n x c2 1\n y obj 2 c1 1\n y c2 3\nRHS\n rhs c1 4 c2 6\nBOUNDS\n UP bnd x 3\nENDATA\n"
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "modelo.mps")
+	_, _ = fw.Write([]byte(src))
+	_ = mw.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/process", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 11.0, resp["optimal_value"], 1e-9)
+
+	// Exportar a MPS y volver a resolver el archivo generado
+	req, _ = http.NewRequest(http.MethodPost, "/export?format=mps&input=mps", strings.NewReader(src))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "modelo.mps")
+	exported := w.Body.String()
+	// Se conservan los nombres del archivo; la cota de x es una fila sin nombre
+	assert.Contains(t, exported, "ROWS\n N  OBJ\n L  c1\n L  c2\n L  C3\n")
+	assert.Contains(t, exported, "    x         c1        1\n")
+
+	req, _ = http.NewRequest(http.MethodPost, "/process?input=mps-fixed", strings.NewReader(exported))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	err = json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 11.0, resp["optimal_value"], 1e-9)
+}
+
+func TestProcess_NamedVariables(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	// Los nombres nil se omiten: "names": null no es una lista
+	request := func(varNames, rowNames []string) *httptest.ResponseRecorder {
+		objective := map[string]any{"n": 2, "coefficients": []float64{3, 2}}
+		if varNames != nil {
+			objective["names"] = varNames
+		}
+		constraints := map[string]any{"rows": 2, "cols": 3, "vars": []float64{1, 1, 4, 1, 3, 6}}
+		if rowNames != nil {
+			constraints["names"] = rowNames
+		}
+		body, _ := json.Marshal(map[string]any{"objective": objective, "constraints": constraints})
+		req, _ := http.NewRequest(http.MethodPost, "/process", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request([]string{"mesas", "sillas"}, []string{"madera", "horas"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Values map[string]float64 `json:"values"`
+		Steps  []struct {
+			ColumnLabels []string `json:"column_labels"`
+		} `json:"steps"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 4.0, resp.Values["mesas"], 1e-9)
+	assert.InDelta(t, 0.0, resp.Values["sillas"], 1e-9)
+	assert.Equal(t, []string{"mesas", "sillas", "s_madera", "s_horas"}, resp.Steps[0].ColumnLabels)
+
+	// Nombres repetidos o cantidad incorrecta
+	assert.Equal(t, http.StatusBadRequest, request([]string{"a", "a"}, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, request([]string{"a"}, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, request(nil, []string{"r", "r"}).Code)
+
+	// Una variable de decisión llamada como una auxiliar: la auxiliar se renombra
+	w = request([]string{"s1", "y"}, nil)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []string{"s1", "y", "s1_", "s2"}, resp.Steps[0].ColumnLabels)
+	assert.InDelta(t, 4.0, resp.Values["s1"], 1e-9)
+}
+
+func TestProcess_ConstraintObjects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/process", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Coeficientes densos por fila, sin rows/cols
+	w := post(`{
+		"objective": {"coefficients": [3, 2], "type": "maximize"},
+		"constraints": [
+			{"coefficients": [1, 1], "rhs": 4, "name": "madera"},
+			{"coefficients": [1, 3], "sign": "<=", "rhs": 6}
+		]
+	}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 12.0, resp["optimal_value"], 1e-9)
+
+	// Objetivo y filas con términos dispersos por nombre
+	w = post(`{
+		"objective": {"terms": [{"var": "x", "coef": 3}, {"var": "y", "coef": 2}], "type": "maximize"},
+		"constraints": [
+			{"terms": [{"var": "x", "coef": 1}, {"var": "y", "coef": 1}], "rhs": 4},
+			{"terms": [{"var": "x", "coef": 1}], "rhs": 3},
+			{"terms": [{"var": "y", "coef": 1}], "sign": "range", "lower": 0.5, "rhs": 2}
+		]
+	}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var named struct {
+		OptimalValue float64            `json:"optimal_value"`
+		Values       map[string]float64 `json:"values"`
+	}
+	err = json.Unmarshal(w.Body.Bytes(), &named)
+	assert.NoError(t, err)
+	assert.InDelta(t, 11.0, named.OptimalValue, 1e-9)
+	assert.InDelta(t, 3.0, named.Values["x"], 1e-9)
+	assert.InDelta(t, 1.0, named.Values["y"], 1e-9)
+
+	// Una fila con la cantidad equivocada de coeficientes se informa con su índice
+	w = post(`{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1, 1], "rhs": 4}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "la restricción 0 tiene 3 coeficientes, se esperaban 2")
+
+	w = post(`{"objective": {"coefficients": [3, 2]}, "constraints": [{"terms": [{"var": "z", "coef": 1}], "rhs": 4}]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "variable desconocida z")
+
+	// Las rutas apuntan a la restricción enviada, no a la matriz posicional, y el
+	// signo inválido del esquema llega en la misma respuesta
+	w = post(`{"objective": {"coefficients": [3, 2]}, "constraints": [
+		{"coefficients": [1, 1], "sign": "<", "rhs": 4},
+		{"coefficients": [1, 0], "sign": "range", "lower": 5, "rhs": 2}
+	]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var invalid validationResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &invalid))
+	assert.Equal(t, []string{"/constraints/0/sign", "/constraints/1/lower"}, issuePaths(invalid.Errors))
+
+	// Un archivo importado no es un documento JSON: los problemas van sin ruta
+	req, _ := http.NewRequest(http.MethodPost, "/process?input=csv", strings.NewReader(",x,y,signo,rhs\nmax,3,2,,\nr,1,1,<=,4\nr,1,3,<=,6\n"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var imported validationResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &imported))
+	if assert.Len(t, imported.Errors, 1, w.Body.String()) {
+		assert.Equal(t, "duplicate_name", imported.Errors[0].Code)
+		assert.Empty(t, imported.Errors[0].Path)
+	}
+}
+
+func TestProcess_CSVUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	src := ",mesas,sillas,signo,rhs\nmax,3,2,,\nmadera,1,1,<=,4\nhoras,1,3,<=,6\n"
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "plan.csv")
+	_, _ = fw.Write([]byte(src))
+	_ = mw.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/process", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		OptimalValue float64            `json:"optimal_value"`
+		Values       map[string]float64 `json:"values"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.InDelta(t, 12.0, resp.OptimalValue, 1e-9)
+	assert.InDelta(t, 4.0, resp.Values["mesas"], 1e-9)
+
+	// Con Content-Type text/csv no hace falta ?input=
+	req, _ = http.NewRequest(http.MethodPost, "/process", strings.NewReader(",x,signo,rhs\nmax,1,,\nc1,2,<=,x\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var perr map[string]any
+	err = json.Unmarshal(w.Body.Bytes(), &perr)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, perr["line"])
+	assert.Equal(t, 4.0, perr["column"])
+}
+
+func TestProcess_LaTeXFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/process?format=latex", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/x-tex")
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "resultado_simplex.tex")
+	assert.True(t, strings.HasPrefix(w.Body.String(), `\documentclass{article}`))
+	assert.Contains(t, w.Body.String(), `\section*{Forma estándar}`)
+	assert.Contains(t, w.Body.String(), `\boxed{`)
+}
+
+func TestProcess_HTMLFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/process?format=html", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "inline")
+	assert.True(t, strings.HasPrefix(w.Body.String(), "<!DOCTYPE html>"))
+	assert.Contains(t, w.Body.String(), `class="pivot"`)
+}
+
+func TestProcess_TraceFormats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+
+	req, _ := http.NewRequest(http.MethodPost, "/process?format=csv", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "iteraciones.zip")
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("PK")))
+
+	req, _ = http.NewRequest(http.MethodPost, "/process?format=ndjson", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)))
+	}
+}
+
+func TestProcess_AcceptNegotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+	send := func(url, accept string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", accept)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("/process", "text/html,application/xhtml+xml;q=0.9,*/*;q=0.8")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+
+	w = send("/process", "application/pdf;q=0.5, application/x-tex")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-tex; charset=utf-8", w.Header().Get("Content-Type"))
+
+	w = send("/process", "*/*")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	// ?format= tiene prioridad sobre Accept
+	w = send("/process?format=ndjson", "application/pdf")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+
+	w = send("/process", "image/png")
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Contains(t, w.Body.String(), "json, pdf")
+
+	// Las iteraciones en CSV van en un zip: text/csv no se puede cumplir
+	w = send("/process", "text/csv")
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+
+	w = send("/process", "application/zip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+	w = send("/process?format=docx", "")
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+}
+
+func TestProcess_TextFormats(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+
+	req, _ := http.NewRequest(http.MethodPost, "/process?format=text", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "[1]")
+
+	req, _ = http.NewRequest(http.MethodPost, "/process", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/markdown")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/markdown; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "| cb | base | x1 | x2 | s1 | s2 | R |")
+}
+
+func TestExport_CodeGeneration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/export", Export())
+
+	body := `{"objective": {"coefficients": [3, 2], "names": ["mesas", "sillas"]}, "constraints": [{"name": "madera", "coefficients": [1, 1], "rhs": 4}]}`
+	send := func(format string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/export?format="+format, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("go")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "main.go")
+	assert.Contains(t, w.Body.String(), "lp.Simplex(cNew, aNew, bNew, 1e-10, nil)")
+
+	w = send("pulp")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"madera"`)
+
+	w = send("ampl")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.True(t, bytes.HasPrefix(w.Body.Bytes(), []byte("PK")))
+}
+
+func TestProcess_GMPLUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	src := `set P;
+param c{P};
+param h{P};
+var x{P} >= 0;
+maximize z: sum{p in P} c[p] * x[p];
+s.t. horas: sum{p in P} h[p] * x[p] <= 6;
+s.t. madera: sum{p in P} x[p] <= 4;
+data;
+set P := mesas sillas;
+param c := mesas 3 sillas 2;
+param h := mesas 1 sillas 3;
+end;
+`
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, _ := mw.CreateFormFile("file", "modelo.mod")
+	_, _ = fw.Write([]byte(src))
+	_ = mw.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/process", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.InDelta(t, 12.0, resp["optimal_value"], 1e-9)
+	assert.InDelta(t, 4.0, resp["values"].(map[string]any)["x[mesas]"], 1e-9)
+
+	// Los errores informan la posición
+	req, _ = http.NewRequest(http.MethodPost, "/process?input=gmpl", strings.NewReader("var x;\nmaximize z: x;"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 1.0, resp["line"])
+}
+
+func TestProcess_AllValidationErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	post := func(body string) validationResponse {
+		req, _ := http.NewRequest(http.MethodPost, "/process", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var resp validationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		return resp
+	}
+
+	// Errores de forma: se informan todos antes de decodificar
+	resp := post(`{
+		"objective": {"n": "2", "coefficients": [1, 2]},
+		"constraints": {"rows": 2, "cols": 3, "vars": [1, 1, 4, 1, 3, 6], "signs": ["<=", ">=", "<"]}
+	}`)
+	assert.Equal(t, []string{"/constraints/signs/2", "/objective/n"}, issuePaths(resp.Errors))
+	assert.Equal(t, "enum", resp.Errors[0].Code)
+	assert.Equal(t, "type", resp.Errors[1].Code)
+	assert.Equal(t, resp.Errors[0].Message, resp.Error)
+
+	// Errores entre campos: rango invertido y nombres repetidos a la vez
+	resp = post(`{
+		"objective": {"n": 2, "coefficients": [1, 2], "names": ["x", "x"]},
+		"constraints": {"rows": 1, "cols": 3, "vars": [1, 1, 4], "signs": ["range"], "lower": [5]}
+	}`)
+	assert.Equal(t, []string{"/constraints/lower/0", "/objective/names/1"}, issuePaths(resp.Errors))
+	assert.Equal(t, "range_inverted", resp.Errors[0].Code)
+	assert.Equal(t, "duplicate_name", resp.Errors[1].Code)
+
+	// Los dos tipos en la misma respuesta: signo inválido según el esquema y
+	// coeficientes que no coinciden con n según Check
+	resp = post(`{
+		"objective": {"n": 2, "coefficients": [1]},
+		"constraints": {"rows": 1, "cols": 3, "vars": [1, 1, 4], "signs": ["<"]}
+	}`)
+	assert.Equal(t, []string{"/constraints/signs/0", "/objective/coefficients"}, issuePaths(resp.Errors))
+	assert.Equal(t, "enum", resp.Errors[0].Code)
+	assert.Equal(t, "length_mismatch", resp.Errors[1].Code)
+
+	// Un valor que viola el esquema y Check se informa una sola vez
+	resp = post(`{
+		"objective": {"n": -1, "coefficients": [1]},
+		"constraints": {"rows": 1, "cols": 2, "vars": [1, 4]}
+	}`)
+	assert.Equal(t, []string{"/objective/n"}, issuePaths(resp.Errors))
+}
+
+func TestColsMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+	router.POST("/standard-form", StandardForm())
+	router.POST("/bases", Bases())
+	router.POST("/export", Export())
+
+	// n = 3 pero cada fila tiene dos columnas: ningún formato llega al solver
+	body := `{"objective": {"n": 3, "coefficients": [1, 1, 1]}, "constraints": {"rows": 1, "cols": 2, "vars": [1, 4]}}`
+	for _, target := range []string{
+		"/process", "/process?format=json", "/process?format=pdf", "/process?format=html",
+		"/process?format=latex", "/process?format=moodle", "/standard-form", "/bases", "/export?format=lp",
+	} {
+		req, _ := http.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+		var resp validationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), target)
+		assert.Equal(t, []string{"/constraints/cols"}, issuePaths(resp.Errors), target)
+		assert.Equal(t, "length_mismatch", resp.Errors[0].Code, target)
+	}
+}
+
+func TestMissingLower(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	// La misma fila de rango sin cota inferior en las dos formas: el error es el
+	// mismo y la ruta apunta al campo que falta en cada documento
+	for body, path := range map[string]string{
+		`{"objective": {"n": 2, "coefficients": [1, 1]}, "constraints": {"rows": 2, "cols": 3, "vars": [1, 1, 4, 1, 0, 3], "signs": ["<=", "range"]}}`:      "/constraints/lower/1",
+		`{"objective": {"coefficients": [1, 1]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 0], "sign": "range", "rhs": 3}]}`: "/constraints/1/lower",
+	} {
+		req, _ := http.NewRequest(http.MethodPost, "/process", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, path)
+		var resp validationResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), path)
+		if assert.Len(t, resp.Errors, 1, path) {
+			assert.Equal(t, path, resp.Errors[0].Path)
+			assert.Equal(t, "missing_lower", resp.Errors[0].Code)
+			assert.Equal(t, "Falta la cota inferior de la restricción de rango 1", resp.Errors[0].Message)
+		}
+	}
+}
+
+func issuePaths(errs []schema.Issue) []string {
+	paths := make([]string, len(errs))
+	for i, e := range errs {
+		paths[i] = e.Path
+	}
+	return paths
+}
+
+func TestSchema(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/schema", Schemas())
+	router.GET("/schema/:version/:name", Schema())
+
+	req, _ := http.NewRequest(http.MethodGet, "/schema/v1/request.json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/schema+json", w.Header().Get("Content-Type"))
+	var doc map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "urn:autosimplex:request:v1", doc["$id"])
+
+	req, _ = http.NewRequest(http.MethodGet, "/schema", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/schema/v1/response.json")
+
+	req, _ = http.NewRequest(http.MethodGet, "/schema/v9/request.json", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestProcess_MoodleFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/process?format=moodle", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "cuestionario_moodle.xml")
+	assert.Contains(t, w.Body.String(), `<question type="multichoice">`)
+	assert.Contains(t, w.Body.String(), "<text>12</text>")
+}
+
+func TestGraphical(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+	router.POST("/graphical", Graphical())
+
+	post := func(url, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+
+	w := post("/graphical", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var g struct {
+		Status  string `json:"status"`
+		Optimum struct {
+			X, Y, Value float64
+		} `json:"optimum"`
+		Vertices []any `json:"vertices"`
+		Path     []struct {
+			X, Y     float64
+			Feasible bool
+		} `json:"path"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &g))
+	assert.Equal(t, "optimal", g.Status)
+	assert.Equal(t, 12.0, g.Optimum.Value)
+	assert.Len(t, g.Vertices, 4)
+	// El simplex sale del origen y llega al óptimo en un pivote
+	if assert.Len(t, g.Path, 2) {
+		assert.Equal(t, 0.0, g.Path[0].X)
+		assert.Equal(t, 4.0, g.Path[1].X)
+		assert.True(t, g.Path[1].Feasible)
+	}
+
+	w = post("/process?format=svg", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/svg+xml", w.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(w.Body.String(), "<svg"))
+
+	// Con tres variables se dibuja el poliedro y el recorrido sobre sus vértices
+	three := `{"objective": {"coefficients": [1, 2, 3]}, "constraints": [{"coefficients": [1, 1, 1], "rhs": 4}]}`
+	w = post("/graphical", three)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var solid struct {
+		Status string `json:"status"`
+		Solid  struct {
+			Vertices []any `json:"vertices"`
+			Optimum  struct {
+				X, Y, Z, Value float64
+			} `json:"optimum"`
+		} `json:"solid"`
+		Path []struct {
+			Values []float64
+		} `json:"path"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &solid))
+	assert.Equal(t, "optimal", solid.Status)
+	assert.Len(t, solid.Solid.Vertices, 4)
+	assert.Equal(t, 12.0, solid.Solid.Optimum.Value)
+	if assert.NotEmpty(t, solid.Path) {
+		assert.Equal(t, []float64{0, 0, 4}, solid.Path[len(solid.Path)-1].Values)
+	}
+	w = post("/process?format=svg", three)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), "<svg"))
+
+	// Con cuatro variables no hay método gráfico
+	four := `{"objective": {"coefficients": [1, 2, 3, 4]}, "constraints": [{"coefficients": [1, 1, 1, 1], "rhs": 4}]}`
+	assert.Equal(t, http.StatusUnprocessableEntity, post("/graphical", four).Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, post("/process?format=svg", four).Code)
+}
+
+func TestBases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/bases", Bases())
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, "/bases", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var tbl struct {
+		Bases  []struct{ Kind string }
+		Counts map[string]int
+		Best   struct {
+			Labels    []string
+			Objective float64
+		}
+		Status string
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &tbl))
+	assert.Len(t, tbl.Bases, 6)
+	assert.Equal(t, map[string]int{"feasible": 4, "infeasible": 2}, tbl.Counts)
+	assert.Equal(t, []string{"x1", "s2"}, tbl.Best.Labels)
+	assert.Equal(t, 12.0, tbl.Best.Objective)
+	assert.Equal(t, "optimal", tbl.Status)
+
+	// 12 restricciones y 15 variables: demasiadas combinaciones
+	row := `{"coefficients": [1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1], "rhs": 1}`
+	rows := strings.TrimSuffix(strings.Repeat(row+",", 12), ",")
+	big := `{"objective": {"coefficients": [1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1]}, "constraints": [` + rows + `]}`
+	assert.Equal(t, http.StatusUnprocessableEntity, post(big).Code)
+}
+
+func TestProcess_CrossCheck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/process", Process())
+
+	post := func(url string) *httptest.ResponseRecorder {
+		body := `{"objective": {"coefficients": [3, 2]}, "constraints": [{"coefficients": [1, 1], "rhs": 4}, {"coefficients": [1, 3], "rhs": 6}]}`
+		req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/process?crosscheck=true")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "agree", w.Header().Get("X-Crosscheck"))
+	var resp struct {
+		Warning    string
+		CrossCheck struct {
+			Solver       string
+			Status       string
+			OptimalValue float64 `json:"optimal_value"`
+			Agree        bool
+		} `json:"crosscheck"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "gonum/lp", resp.CrossCheck.Solver)
+	assert.Equal(t, "optimal", resp.CrossCheck.Status)
+	assert.InDelta(t, 12, resp.CrossCheck.OptimalValue, 1e-9)
+	assert.True(t, resp.CrossCheck.Agree)
+	assert.Empty(t, resp.Warning)
+	assert.Empty(t, schema.Validate(schema.Response, w.Body.Bytes()))
+
+	// Sin el parámetro no se compara
+	w = post("/process")
+	assert.Empty(t, w.Header().Get("X-Crosscheck"))
+	assert.NotContains(t, w.Body.String(), "crosscheck")
+
+	// Los demás formatos llevan el veredicto en el encabezado
+	w = post("/process?format=text&crosscheck=1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "agree", w.Header().Get("X-Crosscheck"))
+
+	assert.Equal(t, http.StatusBadRequest, post("/process?crosscheck=quizas").Code)
+}
+
+func TestStandardForm(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/standard-form", StandardForm())
+
+	body := `{"objective": {"coefficients": [3, 5]}, "constraints": [{"coefficients": [1, 0], "rhs": 4}, {"coefficients": [-3, -2], "rhs": -18}]}`
+	req, _ := http.NewRequest(http.MethodPost, "/standard-form", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var sf struct {
+		Rows []struct {
+			Negated     bool
+			AddedLabels []string `json:"added_labels"`
+			Explanation string
+		}
+		Objective []struct{ Text string }
+		Basis     []string `json:"basis_labels"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &sf))
+	if assert.Len(t, sf.Rows, 2) {
+		assert.False(t, sf.Rows[0].Negated)
+		assert.True(t, sf.Rows[1].Negated)
+		assert.Equal(t, []string{"e2", "a2"}, sf.Rows[1].AddedLabels)
+		assert.Contains(t, sf.Rows[1].Explanation, "a2")
+	}
+	assert.Equal(t, "−M", sf.Objective[4].Text)
+	assert.Equal(t, []string{"s1", "a2"}, sf.Basis)
+}
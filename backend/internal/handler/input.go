@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"autosimplex/internal/gmpl"
+	"autosimplex/internal/lpfile"
+	"autosimplex/internal/models"
+	"autosimplex/internal/mps"
+	"autosimplex/internal/parser"
+	"autosimplex/internal/schema"
+	"autosimplex/internal/sheet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// decoders convierte el contenido recibido en una solicitud, según el modo de entrada.
+var decoders = map[string]func(data []byte) (models.SimplexRequest, error){
+	"json": func(data []byte) (models.SimplexRequest, error) {
+		var req models.SimplexRequest
+		err := json.Unmarshal(data, &req)
+		return req, err
+	},
+	"text": func(data []byte) (models.SimplexRequest, error) {
+		return parser.Parse(string(data))
+	},
+	"lp": func(data []byte) (models.SimplexRequest, error) {
+		return lpfile.Read(bytes.NewReader(data))
+	},
+	"mps": func(data []byte) (models.SimplexRequest, error) {
+		return mps.Read(bytes.NewReader(data))
+	},
+	"mps-fixed": func(data []byte) (models.SimplexRequest, error) {
+		return mps.ReadFixed(bytes.NewReader(data))
+	},
+	"csv": func(data []byte) (models.SimplexRequest, error) {
+		return sheet.ReadCSV(bytes.NewReader(data))
+	},
+	"xlsx": sheet.ReadXLSX,
+	"gmpl": func(data []byte) (models.SimplexRequest, error) {
+		return gmpl.Read(bytes.NewReader(data))
+	},
+}
+
+// extensions asocia la extensión de un archivo subido con su modo de entrada.
+var extensions = map[string]string{
+	".json": "json",
+	".txt":  "text",
+	".lp":   "lp",
+	".mps":  "mps",
+	".csv":  "csv",
+	".xlsx": "xlsx",
+	".mod":  "gmpl",
+}
+
+// inputMode decide cómo leer el cuerpo: el parámetro ?input= tiene prioridad; si
+// falta se usa la extensión del archivo subido y, por último, el Content-Type
+// (text/plain es notación algebraica y text/csv una planilla).
+func inputMode(c *gin.Context, filename string) string {
+	if mode := strings.ToLower(strings.TrimSpace(c.Query("input"))); mode != "" {
+		return mode
+	}
+	if mode, ok := extensions[strings.ToLower(filepath.Ext(filename))]; ok {
+		return mode
+	}
+	switch c.ContentType() {
+	case "text/plain":
+		return "text"
+	case "text/csv":
+		return "csv"
+	}
+	return "json"
+}
+
+// readInput devuelve el contenido a decodificar: el campo "file" si la solicitud
+// es multipart/form-data o, si no, el cuerpo completo.
+func readInput(c *gin.Context) ([]byte, string, error) {
+	if c.ContentType() == "multipart/form-data" {
+		fh, err := c.FormFile("file")
+		if err != nil {
+			return nil, "", errors.New("falta el archivo en el campo 'file'")
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return nil, "", err
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		return data, fh.Filename, err
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	return data, "", err
+}
+
+// bindRequest decodifica la solicitud según el modo de entrada y le aplica las
+// reglas de internal/schema: el esquema al cuerpo JSON y Check a la solicitud
+// decodificada. Los problemas de las dos etapas se responden juntos. Si falla, ya
+// escribió la respuesta de error y devuelve false.
+func bindRequest(c *gin.Context) (models.SimplexRequest, bool) {
+	data, filename, err := readInput(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return models.SimplexRequest{}, false
+	}
+	mode := inputMode(c, filename)
+	decode, ok := decoders[mode]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Modo de entrada no soportado: use json, text, lp, mps, mps-fixed, csv, xlsx o gmpl"})
+		return models.SimplexRequest{}, false
+	}
+	// El esquema informa todos los problemas de forma juntos y con su ruta;
+	// Unmarshal solo reportaría el primero
+	var errs schema.Errors
+	if mode == "json" {
+		errs = schema.Validate(schema.Request, data)
+	}
+	req, err := decode(data)
+	var missing *models.MissingLowerError
+	switch {
+	case errors.As(err, &missing):
+		respondValidation(c, errs.Merge(schema.MissingLower(missing.Rows, formOf(mode, data))))
+		return req, false
+	case err != nil && len(errs) > 0:
+		respondValidation(c, errs)
+		return req, false
+	case err != nil:
+		respondParseError(c, err)
+		return req, false
+	}
+	if errs = errs.Merge(schema.Check(req, formOf(mode, data))); len(errs) > 0 {
+		respondValidation(c, errs)
+		return req, false
+	}
+	return req, true
+}
+
+// formOf devuelve la forma en que llegó la solicitud, para que los errores de
+// schema.Check apunten al documento enviado.
+func formOf(mode string, data []byte) schema.Form {
+	if mode != "json" {
+		return schema.Imported
+	}
+	var doc struct {
+		Constraints json.RawMessage `json:"constraints"`
+	}
+	if json.Unmarshal(data, &doc) == nil && bytes.HasPrefix(bytes.TrimSpace(doc.Constraints), []byte("[")) {
+		return schema.RowObjects
+	}
+	return schema.Matrix
+}
+
+// respondParseError responde 400 e incluye la posición si el error la tiene, o la
+// lista de problemas si es un error de validación del esquema.
+func respondParseError(c *gin.Context, err error) {
+	var errs schema.Errors
+	if errors.As(err, &errs) {
+		respondValidation(c, errs)
+		return
+	}
+	var perr *parser.Error
+	if errors.As(err, &perr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":  err.Error(),
+			"line":   perr.Line,
+			"column": perr.Column,
+		})
+		return
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
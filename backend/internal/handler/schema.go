@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"autosimplex/internal/schema"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Schemas lista los JSON Schema de la versión vigente con la ruta de cada uno.
+func Schemas() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		paths := gin.H{}
+		for _, name := range schema.Names() {
+			paths[name] = "/schema/" + schema.Version + "/" + name + ".json"
+		}
+		c.JSON(http.StatusOK, gin.H{"version": schema.Version, "schemas": paths})
+	}
+}
+
+// Schema sirve un JSON Schema publicado, por ejemplo /schema/v1/request.json.
+func Schema() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		name, _ := strings.CutSuffix(c.Param("name"), ".json")
+		data, ok := schema.Document(c.Param("version"), name)
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Esquema no encontrado: " + c.Param("version") + "/" + c.Param("name")})
+			return
+		}
+		c.Data(http.StatusOK, "application/schema+json", data)
+	}
+}
@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+
+	"autosimplex/internal/simplex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StandardForm devuelve, sin resolver, la transformación del problema a la forma
+// estándar: el cambio de signo de las filas con lado derecho negativo, la
+// holgura, el exceso o la artificial de cada fila con su explicación, el
+// objetivo con la penalidad M y la base inicial.
+func StandardForm() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		req, ok := bindRequest(c)
+		if !ok {
+			return
+		}
+		c.JSON(http.StatusOK, simplex.Standardize(simplex.FromRequest(req)))
+	}
+}
@@ -0,0 +1,242 @@
+// Package htmlreport genera un informe HTML autocontenido (CSS en línea, sin
+// recursos externos) con el modelo, cada iteración y la solución, pensado para
+// compartirlo y leerlo en el teléfono sin conexión.
+package htmlreport
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+//go:embed report.html
+var reportHTML string
+
+var report = template.Must(template.New("report").Parse(reportHTML))
+
+type value struct {
+	Name  string
+	Value string
+}
+
+type cell struct {
+	Text  string
+	Pivot bool
+	// Line marca la fila y la columna del pivote
+	Line bool
+}
+
+type row struct {
+	Cb, Base, R string
+	Cells       []cell
+}
+
+type step struct {
+	Iteration int
+	Labels    []string
+	Cj        []string
+	Rows      []row
+	Zj        []string
+	Z         string
+	Delta     []string
+	Summary   string
+}
+
+type data struct {
+	Sense       string
+	Objective   string
+	Constraints []string
+	NonNegative string
+	Status      string
+	Optimal     bool
+	Value       string
+	Values      []value
+	Warning     string
+	Steps       []step
+}
+
+// Write escribe el informe de req y su resultado res en w.
+func Write(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+	names := decisionNames(req, res)
+	d := data{
+		Sense:       "max",
+		Objective:   expression(req.Objective.Coefficients, names),
+		NonNegative: strings.Join(names, ", "),
+		Status:      statusText(res.Status),
+		Optimal:     res.Status == simplex.StatusOptimal,
+		Value:       simplex.FormatNumber(res.OptimalValue),
+		Warning:     res.Warning,
+	}
+	if strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize") {
+		d.Sense = "min"
+	}
+	d.Constraints = constraints(req, names)
+	for j, v := range res.Solution {
+		if j < len(names) {
+			d.Values = append(d.Values, value{Name: names[j], Value: simplex.FormatNumber(v)})
+		}
+	}
+	for _, st := range res.Steps {
+		d.Steps = append(d.Steps, tableau(st))
+	}
+	return report.Execute(w, d)
+}
+
+// decisionNames devuelve los nombres de las variables de decisión, del catálogo
+// del solver si está o de la solicitud.
+func decisionNames(req models.SimplexRequest, res simplex.Result) []string {
+	names := make([]string, req.Objective.N)
+	for j := range names {
+		names[j] = fmt.Sprintf("x%d", j+1)
+		if j < len(req.Objective.Names) && req.Objective.Names[j] != "" {
+			names[j] = req.Objective.Names[j]
+		}
+	}
+	for _, v := range res.Variables {
+		if v.Kind == simplex.Decision && v.Index <= len(names) {
+			names[v.Index-1] = v.Name
+		}
+	}
+	return names
+}
+
+// constraints escribe cada restricción como "x1 + 3·x2 ≤ 6  (horas)".
+func constraints(req models.SimplexRequest, names []string) []string {
+	n, cols := req.Objective.N, req.Constraints.Cols
+	out := make([]string, 0, req.Constraints.Rows)
+	for i := range req.Constraints.Rows {
+		vals := req.Constraints.Vars[i*cols : (i+1)*cols]
+		lhs, rhs := expression(vals[:n], names), simplex.FormatNumber(vals[n])
+		sign := "<="
+		if i < len(req.Constraints.Signs) {
+			sign = req.Constraints.Signs[i]
+		}
+		var line string
+		switch sign {
+		case "range":
+			lo := 0.0
+			if i < len(req.Constraints.Lower) {
+				lo = req.Constraints.Lower[i]
+			}
+			line = fmt.Sprintf("%s ≤ %s ≤ %s", simplex.FormatNumber(lo), lhs, rhs)
+		case ">=":
+			line = fmt.Sprintf("%s ≥ %s", lhs, rhs)
+		case "=":
+			line = fmt.Sprintf("%s = %s", lhs, rhs)
+		default:
+			line = fmt.Sprintf("%s ≤ %s", lhs, rhs)
+		}
+		if i < len(req.Constraints.Names) && req.Constraints.Names[i] != "" {
+			line += "   (" + req.Constraints.Names[i] + ")"
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// tableau arma los datos de una iteración; el pivote y su fila y columna se marcan.
+func tableau(st simplex.SimplexStep) step {
+	s := step{Iteration: st.Iteration, Summary: summary(st)}
+	if len(st.Table) == 0 {
+		return s
+	}
+	nVars := len(st.Table[0]) - 1
+	for j := range nVars {
+		label := fmt.Sprintf("v%d", j+1)
+		if j < len(st.ColumnLabels) {
+			label = st.ColumnLabels[j]
+		}
+		s.Labels = append(s.Labels, label)
+	}
+	s.Cj = simplex.FormatValues(st.Cj, st.CjM)
+	cb := simplex.FormatValues(st.Cb, st.CbM)
+	for i, r := range st.Table {
+		out := row{R: simplex.FormatNumber(r[nVars])}
+		if i < len(cb) {
+			out.Cb = cb[i]
+		}
+		if i < len(st.BaseLabels) {
+			out.Base = st.BaseLabels[i]
+		}
+		for j := range nVars {
+			out.Cells = append(out.Cells, cell{
+				Text:  simplex.FormatNumber(r[j]),
+				Pivot: i == st.PivotRow && j == st.PivotCol,
+				Line:  (i == st.PivotRow && st.PivotCol >= 0) || (j == st.PivotCol && st.PivotRow >= 0),
+			})
+		}
+		s.Rows = append(s.Rows, out)
+	}
+	s.Zj = simplex.FormatValues(st.Zj, st.ZjM)
+	s.Z = simplex.FormatNumber(st.ObjectiveValue)
+	if st.ObjectiveValueM != nil {
+		s.Z = st.ObjectiveValueM.String()
+	}
+	s.Delta = simplex.FormatValues(st.CjMinusZj, st.CjMinusZjM)
+	return s
+}
+
+// summary describe el pivote de la iteración o, en la última, el veredicto.
+func summary(st simplex.SimplexStep) string {
+	if st.BoundFlip {
+		return fmt.Sprintf("Entra %s, que llega a su cota superior (t = %s): se reemplaza por su complemento y la base no cambia.", st.EnteringLabel, simplex.FormatNumber(st.TValue))
+	}
+	switch st.Status {
+	case simplex.StatusOptimal:
+		return fmt.Sprintf("Tabla óptima. Z = %s", simplex.FormatNumber(st.ObjectiveValue))
+	case simplex.StatusInfeasible:
+		return "Tabla final: quedan variables artificiales positivas en la base, el problema es infactible."
+	case simplex.StatusUnbounded:
+		return fmt.Sprintf("Entra %s, pero su columna no tiene elementos positivos: el problema no está acotado.", st.EnteringLabel)
+	case "":
+		return fmt.Sprintf("Entra %s, sale %s, t = %s.", st.EnteringLabel, st.LeavingLabel, simplex.FormatNumber(st.TValue))
+	}
+	return ""
+}
+
+func statusText(s simplex.Status) string {
+	switch s {
+	case simplex.StatusOptimal:
+		return "Solución óptima"
+	case simplex.StatusInfeasible:
+		return "Problema infactible"
+	case simplex.StatusUnbounded:
+		return "Problema no acotado"
+	case simplex.StatusSingular:
+		return "Base singular"
+	case simplex.StatusIterationLimit:
+		return "Límite de iteraciones"
+	}
+	return "Sin resultado"
+}
+
+// expression escribe "3·x1 − x2 + 2·x3", omitiendo coeficientes nulos.
+func expression(coefs []float64, names []string) string {
+	var b strings.Builder
+	for j, v := range coefs {
+		if simplex.FormatNumber(v) == "0" || j >= len(names) {
+			continue
+		}
+		switch {
+		case b.Len() == 0 && v < 0:
+			b.WriteString("−")
+		case b.Len() > 0 && v < 0:
+			b.WriteString(" − ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		if abs := max(v, -v); simplex.FormatNumber(abs) != "1" {
+			b.WriteString(simplex.FormatNumber(abs) + "·")
+		}
+		b.WriteString(names[j])
+	}
+	if b.Len() == 0 {
+		return "0"
+	}
+	return b.String()
+}
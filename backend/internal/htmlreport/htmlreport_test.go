@@ -0,0 +1,55 @@
+package htmlreport
+
+import (
+	"bytes"
+	"html"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWrite(t *testing.T) {
+	req := models.SimplexRequest{
+		Objective: models.Objective{N: 2, Coefficients: []float64{3, 2}, Names: []string{"mesas", "sillas"}},
+		Constraints: models.Constraints{
+			Rows:  2,
+			Cols:  3,
+			Vars:  []float64{1, 1, 4, 1, 3, 6},
+			Names: []string{"madera", "<horas>"},
+		},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(2, 3, req.Constraints.Vars),
+		VarNames:    req.Objective.Names,
+		RowNames:    req.Constraints.Names,
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req, res))
+	out := buf.String()
+
+	// Autocontenido: CSS en línea y ningún recurso externo
+	assert.Contains(t, out, "<style>")
+	assert.NotContains(t, out, "<link")
+	assert.NotContains(t, out, "<script")
+	assert.NotContains(t, out, "http")
+
+	// html/template escapa "+" y los nombres: comparar el texto visible
+	assert.Contains(t, out, "(&lt;horas&gt;)")
+	text := html.UnescapeString(out)
+	assert.Contains(t, text, "max Z = 3·mesas + 2·sillas")
+	assert.Contains(t, text, "mesas + 3·sillas ≤ 6   (<horas>)")
+	assert.Contains(t, out, `<span class="status optimal">Solución óptima</span>`)
+	assert.Contains(t, out, "Z* = <strong>12</strong>")
+	assert.Contains(t, out, "<li>mesas = <strong>4</strong></li>")
+	assert.Equal(t, len(res.Steps), strings.Count(out, "<table>"))
+	// Un pivote por iteración salvo en la tabla final
+	assert.Equal(t, len(res.Steps)-1, strings.Count(out, `class="pivot"`))
+	assert.Contains(t, out, "Entra mesas, sale s_madera, t = 4.")
+}
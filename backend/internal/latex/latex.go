@@ -0,0 +1,380 @@
+// Package latex escribe el modelo, su forma estándar y cada tabla del simplex como
+// un documento LaTeX listo para compilar o para copiar en apuntes y exámenes.
+package latex
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// Write escribe en w el documento completo: formulación, forma estándar, una tabla
+// por iteración (con el pivote recuadrado) y el resultado.
+func Write(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `\documentclass{article}`)
+	fmt.Fprintln(bw, `\usepackage[utf8]{inputenc}`)
+	fmt.Fprintln(bw, `\usepackage{amsmath}`)
+	fmt.Fprintln(bw, `\usepackage{adjustbox}`)
+	fmt.Fprintln(bw, `\begin{document}`)
+	fmt.Fprintln(bw)
+
+	formulation(bw, req, decisionNames(req, res))
+	if len(res.Steps) > 0 {
+		standardForm(bw, req, res)
+		fmt.Fprintln(bw, `\section*{Tablas}`)
+		for _, st := range res.Steps {
+			tableau(bw, st)
+		}
+	}
+	result(bw, res)
+
+	fmt.Fprintln(bw, `\end{document}`)
+	return bw.Flush()
+}
+
+// decisionNames devuelve los nombres de las variables de decisión: los del
+// catálogo del solver si están, o los de la solicitud.
+func decisionNames(req models.SimplexRequest, res simplex.Result) []string {
+	n := req.Objective.N
+	names := make([]string, n)
+	for j := range names {
+		names[j] = fmt.Sprintf("x%d", j+1)
+		if j < len(req.Objective.Names) && req.Objective.Names[j] != "" {
+			names[j] = req.Objective.Names[j]
+		}
+	}
+	for _, v := range res.Variables {
+		if v.Kind == simplex.Decision && v.Index <= n {
+			names[v.Index-1] = v.Name
+		}
+	}
+	return names
+}
+
+// formulation escribe el modelo tal como fue ingresado.
+func formulation(w io.Writer, req models.SimplexRequest, names []string) {
+	n, cols := req.Objective.N, req.Constraints.Cols
+	fmt.Fprintln(w, `\section*{Formulación}`)
+	fmt.Fprintln(w, `\begin{align*}`)
+	fmt.Fprintf(w, "%s\\quad Z &= %s \\\\\n", sense(req), expression(plain(req.Objective.Coefficients), names))
+	for i := range req.Constraints.Rows {
+		row := req.Constraints.Vars[i*cols : (i+1)*cols]
+		lhs := expression(plain(row[:n]), names)
+		rhs := number(row[n])
+		prefix := ""
+		if i == 0 {
+			prefix = `\text{s.a.}\quad `
+		}
+		sign := "<="
+		if i < len(req.Constraints.Signs) {
+			sign = req.Constraints.Signs[i]
+		}
+		var line string
+		switch sign {
+		case "range":
+			lo := 0.0
+			if i < len(req.Constraints.Lower) {
+				lo = req.Constraints.Lower[i]
+			}
+			line = fmt.Sprintf("%s%s \\le %s &\\le %s", prefix, number(lo), lhs, rhs)
+		case ">=":
+			line = fmt.Sprintf("%s%s &\\ge %s", prefix, lhs, rhs)
+		case "=":
+			line = fmt.Sprintf("%s%s &= %s", prefix, lhs, rhs)
+		default:
+			line = fmt.Sprintf("%s%s &\\le %s", prefix, lhs, rhs)
+		}
+		if i < len(req.Constraints.Names) && req.Constraints.Names[i] != "" {
+			line += fmt.Sprintf(`\qquad \text{(%s)}`, escape(req.Constraints.Names[i]))
+		}
+		fmt.Fprintln(w, line+` \\`)
+	}
+	vars := make([]string, len(names))
+	for j, name := range names {
+		vars[j] = variable(name)
+	}
+	fmt.Fprintf(w, "%s &\\ge 0\n", strings.Join(vars, ", "))
+	fmt.Fprintln(w, `\end{align*}`)
+	fmt.Fprintln(w)
+}
+
+// sense devuelve \max o \min según el tipo de objetivo de req.
+func sense(req models.SimplexRequest) string {
+	if strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize") {
+		return `\min`
+	}
+	return `\max`
+}
+
+// standardForm escribe el sistema que resuelve el solver. La tabla inicial parte
+// de la base de holguras y artificiales (la identidad), así que sus filas son
+// exactamente las ecuaciones de la forma estándar.
+func standardForm(w io.Writer, req models.SimplexRequest, res simplex.Result) {
+	first := res.Steps[0]
+	if len(first.Table) == 0 || len(first.ColumnLabels) == 0 {
+		return
+	}
+	labels := first.ColumnLabels
+	costs := first.CjM
+	if len(costs) != len(labels) {
+		costs = plain(first.Cj)
+	}
+
+	fmt.Fprintln(w, `\section*{Forma estándar}`)
+	fmt.Fprintln(w, `\begin{align*}`)
+	fmt.Fprintf(w, "%s\\quad Z &= %s \\\\\n", sense(req), expression(costs, labels))
+	for _, row := range first.Table {
+		fmt.Fprintf(w, "%s &= %s \\\\\n", expression(plain(row[:len(row)-1]), labels), number(row[len(row)-1]))
+	}
+	vars := make([]string, len(labels))
+	for j, name := range labels {
+		vars[j] = variable(name)
+	}
+	fmt.Fprintf(w, "%s &\\ge 0", strings.Join(vars, ", "))
+	for _, v := range res.Variables {
+		if v.Upper != nil {
+			fmt.Fprintf(w, ` \\`+"\n"+`%s &\le %s`, variable(v.Name), number(*v.Upper))
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `\end{align*}`)
+	fmt.Fprintln(w)
+}
+
+// tableau escribe una iteración como tabular con las filas cj, Zj y Cj - Zj.
+func tableau(w io.Writer, st simplex.SimplexStep) {
+	if len(st.Table) == 0 {
+		return
+	}
+	nVars := len(st.Table[0]) - 1
+	label := func(j int) string {
+		if j < len(st.ColumnLabels) {
+			return variable(st.ColumnLabels[j])
+		}
+		return fmt.Sprintf("v_{%d}", j+1)
+	}
+
+	fmt.Fprintf(w, "\\subsection*{Iteración %d}\n", st.Iteration)
+	fmt.Fprintln(w, `\begin{center}`)
+	fmt.Fprintln(w, `\begin{adjustbox}{max width=\linewidth}`)
+	fmt.Fprintf(w, "\\begin{tabular}{cc|%s|c}\n", strings.Repeat("c", nVars))
+
+	cells := []string{"", "$c_j$"}
+	for _, v := range values(st.Cj, st.CjM) {
+		cells = append(cells, "$"+v+"$")
+	}
+	fmt.Fprintln(w, strings.Join(append(cells, ""), " & ")+` \\`)
+
+	cells = []string{"$c_b$", "Base"}
+	for j := range nVars {
+		cells = append(cells, "$"+label(j)+"$")
+	}
+	fmt.Fprintln(w, strings.Join(append(cells, "$R$"), " & ")+` \\ \hline`)
+
+	cb := values(st.Cb, st.CbM)
+	for i, row := range st.Table {
+		cells = []string{"", ""}
+		if i < len(cb) {
+			cells[0] = "$" + cb[i] + "$"
+		}
+		if i < len(st.BaseLabels) {
+			cells[1] = "$" + variable(st.BaseLabels[i]) + "$"
+		}
+		for j := range nVars {
+			cell := number(row[j])
+			if i == st.PivotRow && j == st.PivotCol {
+				cell = `\boxed{` + cell + `}`
+			}
+			cells = append(cells, "$"+cell+"$")
+		}
+		cells = append(cells, "$"+number(row[nVars])+"$")
+		fmt.Fprintln(w, strings.Join(cells, " & ")+` \\`)
+	}
+
+	if len(st.Zj) > 0 {
+		fmt.Fprintln(w, `\hline`)
+		cells = []string{"", "$Z_j$"}
+		for _, v := range values(st.Zj, st.ZjM) {
+			cells = append(cells, "$"+v+"$")
+		}
+		z := number(st.ObjectiveValue)
+		if st.ObjectiveValueM != nil {
+			z = symbolic(*st.ObjectiveValueM)
+		}
+		fmt.Fprintln(w, strings.Join(append(cells, "$"+z+"$"), " & ")+` \\`)
+	}
+	if len(st.CjMinusZj) > 0 {
+		cells = []string{"", "$C_j - Z_j$"}
+		for _, v := range values(st.CjMinusZj, st.CjMinusZjM) {
+			cells = append(cells, "$"+v+"$")
+		}
+		fmt.Fprintln(w, strings.Join(append(cells, ""), " & ")+` \\`)
+	}
+	fmt.Fprintln(w, `\end{tabular}`)
+	fmt.Fprintln(w, `\end{adjustbox}`)
+	fmt.Fprintln(w, `\end{center}`)
+	fmt.Fprintln(w, summary(st))
+	fmt.Fprintln(w)
+}
+
+// summary describe el pivote de la iteración o, en la última, el veredicto.
+func summary(st simplex.SimplexStep) string {
+	entering, leaving := "$"+variable(st.EnteringLabel)+"$", "$"+variable(st.LeavingLabel)+"$"
+	if st.BoundFlip {
+		return fmt.Sprintf("Entra %s, que llega a su cota superior ($t = %s$): se reemplaza por su complemento y la base no cambia.", entering, number(st.TValue))
+	}
+	switch st.Status {
+	case simplex.StatusOptimal:
+		return fmt.Sprintf("Tabla óptima: ningún $C_j - Z_j$ mejora el objetivo. $Z = %s$.", number(st.ObjectiveValue))
+	case simplex.StatusInfeasible:
+		return "Tabla final: quedan variables artificiales positivas en la base, el problema es infactible."
+	case simplex.StatusUnbounded:
+		return fmt.Sprintf("Entra %s, pero su columna no tiene elementos positivos: el problema no está acotado.", entering)
+	case "":
+		return fmt.Sprintf("Entra %s, sale %s, $t = %s$.", entering, leaving, number(st.TValue))
+	}
+	return ""
+}
+
+// result escribe el veredicto y, si hay solución, los valores de las variables.
+func result(w io.Writer, res simplex.Result) {
+	fmt.Fprintln(w, `\section*{Resultado}`)
+	switch res.Status {
+	case simplex.StatusOptimal:
+		var values []string
+		for _, v := range res.Variables {
+			if v.Kind == simplex.Decision && v.Index <= len(res.Solution) {
+				values = append(values, fmt.Sprintf("%s = %s", variable(v.Name), number(res.Solution[v.Index-1])))
+			}
+		}
+		fmt.Fprintf(w, "Solución óptima: $Z^* = %s$, con $%s$.\n", number(res.OptimalValue), strings.Join(values, `,\; `))
+	case simplex.StatusInfeasible:
+		fmt.Fprintln(w, "El problema es infactible.")
+	case simplex.StatusUnbounded:
+		fmt.Fprintln(w, "El problema no está acotado.")
+	default:
+		fmt.Fprintln(w, "El algoritmo terminó sin encontrar una solución óptima.")
+	}
+	if res.Warning != "" {
+		fmt.Fprintf(w, "\n\\textit{%s}\n", escape(res.Warning))
+	}
+	fmt.Fprintln(w)
+}
+
+// expression escribe "3x_{1} - x_{2} + (2 - M)a_{1}", omitiendo coeficientes nulos.
+func expression(coefs []simplex.MValue, names []string) string {
+	var b strings.Builder
+	for j, c := range coefs {
+		c = simplex.MValue{Const: round(c.Const), M: round(c.M)}
+		if (c.Const == 0 && c.M == 0) || j >= len(names) {
+			continue
+		}
+		// El signo del término es el de la parte dominante (la M, si existe)
+		neg := c.M < 0 || (c.M == 0 && c.Const < 0)
+		mixed := c.Const != 0 && c.M != 0
+		if neg && !mixed {
+			c = simplex.MValue{Const: -c.Const, M: -c.M}
+		}
+		switch {
+		case b.Len() == 0 && neg && !mixed:
+			b.WriteString("-")
+		case b.Len() > 0 && neg && !mixed:
+			b.WriteString(" - ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		switch {
+		case mixed:
+			b.WriteString("(" + symbolic(c) + ")")
+		case c.M == 0 && c.Const != 1:
+			b.WriteString(number(c.Const))
+		case c.M != 0 && c.M != 1:
+			b.WriteString(number(c.M) + "M")
+		case c.M == 1:
+			b.WriteString("M")
+		}
+		b.WriteString(variable(names[j]))
+	}
+	if b.Len() == 0 {
+		return "0"
+	}
+	return b.String()
+}
+
+// values formatea una fila del tableau como los demás informes, con el guion
+// ASCII.
+func values(v []float64, sym []simplex.MValue) []string {
+	out := simplex.FormatValues(v, sym)
+	for i := range out {
+		out[i] = strings.ReplaceAll(out[i], "−", "-")
+	}
+	return out
+}
+
+func plain(v []float64) []simplex.MValue {
+	out := make([]simplex.MValue, len(v))
+	for i, x := range v {
+		out[i] = simplex.MValue{Const: x}
+	}
+	return out
+}
+
+// symbolic escribe "3 - 2M" con el guion ASCII, que LaTeX convierte en signo menos.
+func symbolic(v simplex.MValue) string {
+	return strings.ReplaceAll(v.String(), "−", "-")
+}
+
+// number imprime v con hasta 4 decimales y el guion ASCII.
+func number(v float64) string {
+	return strings.ReplaceAll(simplex.FormatNumber(v), "−", "-")
+}
+
+func round(v float64) float64 {
+	r := math.Round(v*1e4) / 1e4
+	if r == 0 {
+		return 0
+	}
+	return r
+}
+
+var (
+	// indexed reconoce nombres como x1, s12 o x3' (complemento)
+	indexed = regexp.MustCompile(`^([A-Za-z])(\d+)('?)$`)
+	// auxiliary reconoce las holguras de filas con nombre: s_madera, a_pedido
+	auxiliary = regexp.MustCompile(`^([sea])_(.+?)('?)$`)
+)
+
+// variable escribe un nombre de variable en modo matemático: x1 como x_{1},
+// s_madera como s_{\text{madera}} y cualquier otro como \text{...}.
+func variable(name string) string {
+	if m := indexed.FindStringSubmatch(name); m != nil {
+		return fmt.Sprintf("%s_{%s}%s", m[1], m[2], m[3])
+	}
+	if m := auxiliary.FindStringSubmatch(name); m != nil {
+		return fmt.Sprintf(`%s_{\text{%s}}%s`, m[1], escape(m[2]), m[3])
+	}
+	if len(name) == 1 && unicode.IsLetter(rune(name[0])) {
+		return name
+	}
+	return `\text{` + escape(name) + `}`
+}
+
+// escape protege los caracteres especiales de LaTeX.
+func escape(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		`{`, `\{`, `}`, `\}`,
+		`_`, `\_`, `^`, `\^{}`,
+		`#`, `\#`, `$`, `\$`, `%`, `\%`, `&`, `\&`,
+		`~`, `\~{}`,
+	)
+	return r.Replace(s)
+}
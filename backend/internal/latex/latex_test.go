@@ -0,0 +1,83 @@
+package latex
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWrite(t *testing.T) {
+	// max 3 x1 + 2 x2 con una fila >= (gran M) y una fila con nombre
+	req := models.SimplexRequest{
+		Objective: models.Objective{N: 2, Coefficients: []float64{3, 2}, Type: "maximize"},
+		Constraints: models.Constraints{
+			Rows:  2,
+			Cols:  3,
+			Vars:  []float64{1, 1, 4, 1, 0, 1},
+			Signs: []string{"<=", ">="},
+			Names: []string{"madera", ""},
+		},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(2, 3, req.Constraints.Vars),
+		Signs:       req.Constraints.Signs,
+		RowNames:    req.Constraints.Names,
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req, res))
+	out := buf.String()
+
+	assert.Contains(t, out, `\max\quad Z &= 3x_{1} + 2x_{2} \\`)
+	assert.Contains(t, out, `\text{s.a.}\quad x_{1} + x_{2} &\le 4\qquad \text{(madera)} \\`)
+	assert.Contains(t, out, `x_{1} &\ge 1 \\`)
+	// Forma estándar con holgura nombrada, exceso y artificial penalizada
+	assert.Contains(t, out, `\max\quad Z &= 3x_{1} + 2x_{2} - Ma_{2} \\`)
+	assert.Contains(t, out, `x_{1} + x_{2} + s_{\text{madera}} &= 4 \\`)
+	assert.Contains(t, out, `x_{1} - e_{2} + a_{2} &= 1 \\`)
+	assert.Contains(t, out, `\boxed{1}`)
+	assert.Contains(t, out, "$-M$")
+	assert.Contains(t, out, `Solución óptima: $Z^* = 12$, con $x_{1} = 4,\; x_{2} = 0$.`)
+
+	// Cada entorno abierto se cierra
+	for _, env := range []string{"document", "align*", "tabular", "adjustbox", "center"} {
+		assert.Equal(t, strings.Count(out, `\begin{`+env+`}`), strings.Count(out, `\end{`+env+`}`), env)
+	}
+	assert.Equal(t, len(res.Steps), strings.Count(out, `\begin{tabular}`))
+}
+
+func TestWriteMinimize(t *testing.T) {
+	// min x1 + x2 con x1 + x2 >= 2: las dos secciones llevan \min
+	req := models.SimplexRequest{
+		Objective:   models.Objective{N: 2, Coefficients: []float64{1, 1}, Type: "minimize"},
+		Constraints: models.Constraints{Rows: 1, Cols: 3, Vars: []float64{1, 1, 2}, Signs: []string{">="}},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(1, 3, req.Constraints.Vars),
+		Signs:       req.Constraints.Signs,
+		Minimize:    true,
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req, res))
+	out := buf.String()
+	assert.Contains(t, out, `\min\quad Z &= x_{1} + x_{2} \\`)
+	assert.Contains(t, out, `\min\quad Z &= x_{1} + x_{2} + Ma_{1} \\`)
+	assert.NotContains(t, out, `\max`)
+}
+
+func TestVariable(t *testing.T) {
+	assert.Equal(t, "x_{12}", variable("x12"))
+	assert.Equal(t, "s_{3}'", variable("s3'"))
+	assert.Equal(t, `s_{\text{horas\_extra}}`, variable("s_horas_extra"))
+	assert.Equal(t, "y", variable("y"))
+	assert.Equal(t, `\text{mesas\&sillas}`, variable("mesas&sillas"))
+}
@@ -0,0 +1,118 @@
+package lpfile
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sample = `\ Problema de mezcla
+Maximize
+ obj: 3 x + 2 y
+    + 4 z
+Subject To
+ c1: x + y + z <= 10
+ c2: 2 x - y >= -2
+ c3: 1 <= y + z <= 6
+Bounds
+ x <= 4
+ 0 <= z <= 3
+General
+ x
+End
+`
+
+func TestRead(t *testing.T) {
+	req, err := Read(strings.NewReader(sample))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "maximize", req.Objective.Type)
+	assert.Equal(t, []float64{3, 2, 4}, req.Objective.Coefficients)
+	// 3 restricciones + 2 cotas superiores
+	assert.Equal(t, 5, req.Constraints.Rows)
+	assert.Equal(t, []string{"<=", ">=", "range", "<=", "<="}, req.Constraints.Signs)
+	assert.Equal(t, []float64{
+		1, 1, 1, 10,
+		2, -1, 0, -2,
+		0, 1, 1, 6,
+		1, 0, 0, 4,
+		0, 0, 1, 3,
+	}, req.Constraints.Vars)
+	assert.Equal(t, 1.0, req.Constraints.Lower[2])
+	assert.Equal(t, []int{0}, req.Integers)
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	req, err := Read(strings.NewReader(sample))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req))
+	assert.Contains(t, buf.String(), "Maximize")
+	assert.Contains(t, buf.String(), "c3: 1 <= y + z <= 6")
+	// Las filas que vienen de cotas no tienen nombre y se escriben sin etiqueta
+	assert.Contains(t, buf.String(), "\n x <= 4\n")
+
+	again, err := Read(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, req, again)
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := Read(strings.NewReader("Maximize\n obj: x\nSubject To\n c1: x + y 4\nEnd\n"))
+	var perr *parser.Error
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, 4, perr.Line)
+		assert.Equal(t, 12, perr.Column)
+	}
+
+	_, err = Read(strings.NewReader("Minimize\n obj: x\nSubject To\n c1: x <= 4\nBounds\n x free\nEnd\n"))
+	assert.Error(t, err)
+}
+
+func TestWriteFallbackNames(t *testing.T) {
+	// "a b" no es un nombre LP válido y su reemplazo x2 ya está usado
+	req := models.SimplexRequest{
+		Objective:   models.Objective{N: 2, Coefficients: []float64{1, 2}, Names: []string{"x2", "a b"}},
+		Constraints: models.Constraints{Rows: 1, Cols: 3, Vars: []float64{1, 1, 4}},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req))
+	assert.Contains(t, buf.String(), "obj: x2 + 2 x2_")
+
+	again, err := Read(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, again.Objective.N)
+	assert.Equal(t, []float64{1, 2}, again.Objective.Coefficients)
+}
+
+func TestWriteKeywordNames(t *testing.T) {
+	// Las palabras reservadas no se escriben como nombres: al comienzo de una
+	// línea se leerían como una sección
+	req := models.SimplexRequest{
+		Objective: models.Objective{N: 3, Coefficients: []float64{1, 2, 3}, Names: []string{"End", "free", "y"}},
+		Constraints: models.Constraints{
+			Rows: 3, Cols: 4, Names: []string{"bounds", "ST", "general"},
+			Vars:  []float64{1, 1, 0, 4, 0, 1, 1, 5, 1, 0, 1, 6},
+			Signs: []string{"<=", ">=", "="},
+		},
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req))
+	assert.Contains(t, buf.String(), "obj: x1 + 2 x2 + 3 y")
+	assert.Contains(t, buf.String(), " c1: x1 + x2 <= 4")
+	assert.Contains(t, buf.String(), " c3: x1 + y = 6")
+
+	again, err := Read(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3}, again.Objective.Coefficients)
+	assert.Equal(t, req.Constraints.Vars, again.Constraints.Vars)
+	assert.Equal(t, req.Constraints.Signs, again.Constraints.Signs)
+	assert.Equal(t, []string{"c1", "c2", "c3"}, again.Constraints.Names)
+}
@@ -0,0 +1,502 @@
+// Package lpfile lee y escribe modelos en formato CPLEX LP, con las secciones
+// Maximize/Minimize, Subject To, Bounds, General, Binary y End.
+package lpfile
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+)
+
+// Read lee un archivo LP y lo convierte en una solicitud para el solver.
+func Read(r io.Reader) (models.SimplexRequest, error) {
+	m, err := ReadModel(r)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
+
+type section int
+
+const (
+	sNone section = iota
+	sObjective
+	sConstraints
+	sBounds
+	sGeneral
+	sBinary
+	sEnd
+)
+
+var reSection = regexp.MustCompile(`(?i)^\s*(maximize|maximise|maximum|max|minimize|minimise|minimum|min|subject\s+to|such\s+that|s\.t\.|st|bounds|bound|generals|general|gen|integers|integer|binaries|binary|bin|semi-continuous|semis|semi|sos|end)(?:\W|$)`)
+
+// token de un archivo LP. Las secciones pueden ocupar varias líneas, así que cada
+// token guarda su propia posición.
+type token struct {
+	kind int
+	text string
+	num  float64
+	line int
+	col  int
+}
+
+const (
+	tNumber = iota
+	tIdent
+	tSign
+	tCmp
+	tColon
+	tEOF
+)
+
+// ReadModel lee un archivo LP y devuelve el modelo con nombres, sin aplanarlo.
+func ReadModel(r io.Reader) (models.LinearModel, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return models.LinearModel{}, err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	// Repartir los tokens por sección
+	tokens := map[section][]token{}
+	current := sNone
+	seen := map[section]bool{}
+	var m models.LinearModel
+	for i, raw := range lines {
+		lineNo := i + 1
+		text := raw
+		if j := strings.Index(text, `\`); j >= 0 {
+			text = text[:j]
+		}
+		offset := 0
+		if loc := reSection.FindStringSubmatchIndex(text); loc != nil {
+			kw := strings.ToLower(strings.Join(strings.Fields(text[loc[2]:loc[3]]), " "))
+			next := sectionOf(kw)
+			if next == sNone {
+				return m, &parser.Error{Line: lineNo, Column: loc[2] + 1, Msg: fmt.Sprintf("sección %q no soportada", text[loc[2]:loc[3]])}
+			}
+			if next == sObjective {
+				if seen[sObjective] {
+					return m, &parser.Error{Line: lineNo, Column: loc[2] + 1, Msg: "la función objetivo aparece dos veces"}
+				}
+				m.Sense = "maximize"
+				if strings.HasPrefix(kw, "min") {
+					m.Sense = "minimize"
+				}
+			}
+			current = next
+			seen[next] = true
+			offset = loc[3]
+			if current == sEnd {
+				break
+			}
+		}
+		toks, err := lexLP(text[offset:], lineNo, len([]rune(text[:offset])))
+		if err != nil {
+			return m, err
+		}
+		if len(toks) > 0 && current == sNone {
+			return m, &parser.Error{Line: lineNo, Column: toks[0].col, Msg: "se esperaba Maximize o Minimize"}
+		}
+		tokens[current] = append(tokens[current], toks...)
+	}
+	if !seen[sObjective] {
+		return m, &parser.Error{Line: 1, Column: 1, Msg: "falta la sección Maximize o Minimize"}
+	}
+	end := token{kind: tEOF, line: len(lines), col: 1}
+
+	s := &stream{toks: append(tokens[sObjective], end)}
+	if s.peek().kind == tIdent && s.peekAt(1).kind == tColon {
+		s.pos += 2
+	}
+	if s.peek().kind != tEOF {
+		terms, constant, err := s.expr()
+		if err != nil {
+			return m, err
+		}
+		if constant != 0 {
+			return m, s.errorf(s.peek(), "la función objetivo no admite términos constantes")
+		}
+		m.Objective = terms
+	}
+	if t := s.peek(); t.kind != tEOF {
+		return m, s.errorf(t, "se esperaba el fin de la función objetivo")
+	}
+
+	s = &stream{toks: append(tokens[sConstraints], end)}
+	for s.peek().kind != tEOF {
+		row, err := s.constraint()
+		if err != nil {
+			return m, err
+		}
+		m.Rows = append(m.Rows, row)
+	}
+
+	s = &stream{toks: append(tokens[sBounds], end)}
+	bounds := map[string]*models.Bound{}
+	var order []string
+	for s.peek().kind != tEOF {
+		first := s.peek()
+		v, lo, hi, err := s.bound()
+		if err != nil {
+			return m, err
+		}
+		b, ok := bounds[v]
+		if !ok {
+			b = &models.Bound{Var: v, Lower: 0, Upper: math.Inf(1)}
+			bounds[v] = b
+			order = append(order, v)
+		}
+		if lo != nil {
+			b.Lower = *lo
+		}
+		if hi != nil {
+			b.Upper = *hi
+		}
+		if b.Lower < 0 {
+			return m, &parser.Error{Line: first.line, Column: first.col, Msg: fmt.Sprintf("cota inferior negativa o variable libre %s no soportada: todas las variables deben ser >= 0", v)}
+		}
+	}
+
+	for _, sec := range []section{sGeneral, sBinary} {
+		for _, t := range tokens[sec] {
+			if t.kind != tIdent {
+				return m, &parser.Error{Line: t.line, Column: t.col, Msg: "se esperaba una lista de variables"}
+			}
+			m.Integers = append(m.Integers, t.text)
+			if sec == sBinary {
+				if _, ok := bounds[t.text]; !ok {
+					order = append(order, t.text)
+				}
+				bounds[t.text] = &models.Bound{Var: t.text, Lower: 0, Upper: 1}
+			}
+		}
+	}
+	for _, v := range order {
+		m.Bounds = append(m.Bounds, *bounds[v])
+	}
+	return m, nil
+}
+
+func sectionOf(kw string) section {
+	switch {
+	case strings.HasPrefix(kw, "max"), strings.HasPrefix(kw, "min"):
+		return sObjective
+	case kw == "subject to" || kw == "such that" || kw == "s.t." || kw == "st":
+		return sConstraints
+	case strings.HasPrefix(kw, "bound"):
+		return sBounds
+	case strings.HasPrefix(kw, "gen"), strings.HasPrefix(kw, "integer"):
+		return sGeneral
+	case strings.HasPrefix(kw, "bin"):
+		return sBinary
+	case kw == "end":
+		return sEnd
+	}
+	return sNone
+}
+
+// lexLP divide una línea en tokens. Los nombres LP admiten varios símbolos además
+// de letras y dígitos, pero no pueden empezar con un dígito ni con un punto.
+func lexLP(line string, lineNo, offset int) ([]token, error) {
+	rs := []rune(line)
+	var toks []token
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		col := offset + i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			start := i
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			if i < len(rs) && (rs[i] == 'e' || rs[i] == 'E') {
+				j := i + 1
+				if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+					j++
+				}
+				if j < len(rs) && unicode.IsDigit(rs[j]) {
+					for i = j; i < len(rs) && unicode.IsDigit(rs[i]); i++ {
+					}
+				}
+			}
+			v, err := strconv.ParseFloat(string(rs[start:i]), 64)
+			if err != nil {
+				return nil, &parser.Error{Line: lineNo, Column: col, Msg: fmt.Sprintf("número inválido %q", string(rs[start:i]))}
+			}
+			toks = append(toks, token{kind: tNumber, num: v, text: string(rs[start:i]), line: lineNo, col: col})
+		case r == '+' || r == '-':
+			toks = append(toks, token{kind: tSign, text: string(r), line: lineNo, col: col})
+			i++
+		case r == '<' || r == '>' || r == '=':
+			text := string(r)
+			i++
+			if i < len(rs) && (rs[i] == '=' || (r == '=' && (rs[i] == '<' || rs[i] == '>'))) {
+				text += string(rs[i])
+				i++
+			}
+			cmp := "="
+			if strings.Contains(text, "<") {
+				cmp = "<="
+			} else if strings.Contains(text, ">") {
+				cmp = ">="
+			}
+			toks = append(toks, token{kind: tCmp, text: cmp, line: lineNo, col: col})
+		case r == ':':
+			toks = append(toks, token{kind: tColon, line: lineNo, col: col})
+			i++
+		case isNameRune(r):
+			start := i
+			for i < len(rs) && (isNameRune(rs[i]) || unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{kind: tIdent, text: string(rs[start:i]), line: lineNo, col: col})
+		default:
+			return nil, &parser.Error{Line: lineNo, Column: col, Msg: fmt.Sprintf("carácter inesperado %q", r)}
+		}
+	}
+	return toks, nil
+}
+
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || strings.ContainsRune("_!\"#$%&()/,;?@`'{}|~[]", r)
+}
+
+type stream struct {
+	toks []token
+	pos  int
+}
+
+func (s *stream) peek() token { return s.toks[s.pos] }
+
+func (s *stream) peekAt(k int) token {
+	if s.pos+k < len(s.toks) {
+		return s.toks[s.pos+k]
+	}
+	return s.toks[len(s.toks)-1]
+}
+
+func (s *stream) next() token {
+	t := s.toks[s.pos]
+	if t.kind != tEOF {
+		s.pos++
+	}
+	return t
+}
+
+func (s *stream) errorf(t token, format string, args ...any) *parser.Error {
+	return &parser.Error{Line: t.line, Column: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expr lee una expresión lineal hasta una comparación, un nombre de restricción
+// ("c2:") o el fin de la sección. Devuelve los términos y la suma de constantes.
+func (s *stream) expr() ([]models.Term, float64, error) {
+	var terms []models.Term
+	index := map[string]int{}
+	constant := 0.0
+	first := true
+	for {
+		t := s.peek()
+		if t.kind == tCmp || t.kind == tEOF || (t.kind == tIdent && s.peekAt(1).kind == tColon) {
+			if first {
+				return nil, 0, s.errorf(t, "se esperaba un número o una variable")
+			}
+			return terms, constant, nil
+		}
+		sign, sawSign := 1.0, false
+		for t.kind == tSign {
+			if t.text == "-" {
+				sign = -sign
+			}
+			sawSign = true
+			s.next()
+			t = s.peek()
+		}
+		if !first && !sawSign {
+			return terms, constant, nil
+		}
+		coef, hasNumber := 1.0, false
+		if t.kind == tNumber {
+			coef, hasNumber = t.num, true
+			s.next()
+			t = s.peek()
+		}
+		switch {
+		case t.kind == tIdent && s.peekAt(1).kind != tColon:
+			s.next()
+			if j, ok := index[t.text]; ok {
+				terms[j].Coef += sign * coef
+			} else {
+				index[t.text] = len(terms)
+				terms = append(terms, models.Term{Var: t.text, Coef: sign * coef})
+			}
+		case hasNumber:
+			constant += sign * coef
+		default:
+			return nil, 0, s.errorf(t, "se esperaba un número o una variable")
+		}
+		first = false
+	}
+}
+
+// constant lee un número con signo opcional; admite "inf" e "infinity".
+func (s *stream) constant() (float64, error) {
+	sign := 1.0
+	t := s.peek()
+	for t.kind == tSign {
+		if t.text == "-" {
+			sign = -sign
+		}
+		s.next()
+		t = s.peek()
+	}
+	switch {
+	case t.kind == tNumber:
+		s.next()
+		return sign * t.num, nil
+	case t.kind == tIdent && (strings.EqualFold(t.text, "inf") || strings.EqualFold(t.text, "infinity")):
+		s.next()
+		return sign * math.Inf(1), nil
+	}
+	return 0, s.errorf(t, "se esperaba un número")
+}
+
+// isConstantAhead indica si lo que sigue es una constante seguida de una
+// comparación, como en "2 <= x + y <= 5".
+func (s *stream) isConstantAhead() bool {
+	k := 0
+	for s.peekAt(k).kind == tSign {
+		k++
+	}
+	t := s.peekAt(k)
+	isConst := t.kind == tNumber || (t.kind == tIdent && (strings.EqualFold(t.text, "inf") || strings.EqualFold(t.text, "infinity")))
+	return isConst && s.peekAt(k+1).kind == tCmp
+}
+
+// constraint lee "[nombre:] expr op constante" o "[nombre:] lo <= expr <= hi".
+func (s *stream) constraint() (models.Row, error) {
+	var row models.Row
+	if s.peek().kind == tIdent && s.peekAt(1).kind == tColon {
+		row.Name = s.next().text
+		s.next()
+	}
+	start := s.peek()
+	if s.isConstantAhead() {
+		lo, err := s.constant()
+		if err != nil {
+			return row, err
+		}
+		op1 := s.next()
+		terms, c, err := s.expr()
+		if err != nil {
+			return row, err
+		}
+		op2 := s.next()
+		if op2.kind != tCmp || op2.text != op1.text || op1.text == "=" {
+			return row, s.errorf(op2, "un rango necesita dos comparaciones en el mismo sentido")
+		}
+		hi, err := s.constant()
+		if err != nil {
+			return row, err
+		}
+		if op1.text == ">=" {
+			lo, hi = hi, lo
+		}
+		row.Terms, row.Sign, row.RHS, row.Lower = terms, "range", hi-c, lo-c
+		if row.Lower > row.RHS {
+			return row, s.errorf(start, "el límite inferior del rango supera al superior")
+		}
+		return row, nil
+	}
+	terms, c, err := s.expr()
+	if err != nil {
+		return row, err
+	}
+	op := s.next()
+	if op.kind != tCmp {
+		return row, s.errorf(op, "se esperaba un operador de comparación (<=, >=, =)")
+	}
+	rhs, err := s.constant()
+	if err != nil {
+		return row, err
+	}
+	if math.IsInf(rhs, 0) {
+		return row, s.errorf(op, "el lado derecho debe ser finito")
+	}
+	row.Terms, row.Sign, row.RHS = terms, op.text, rhs-c
+	return row, nil
+}
+
+// bound lee una cota: "x <= 4", "x >= 1", "x = 2", "0 <= x <= 4" o "x free".
+// Devuelve nil en los extremos que la línea no fija.
+func (s *stream) bound() (string, *float64, *float64, error) {
+	if s.peek().kind == tIdent && s.peekAt(1).kind == tIdent && strings.EqualFold(s.peekAt(1).text, "free") {
+		v := s.next().text
+		s.next()
+		lo := math.Inf(-1)
+		return v, &lo, nil, nil
+	}
+	if s.isConstantAhead() {
+		lo, err := s.constant()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		op1 := s.next()
+		v := s.next()
+		if v.kind != tIdent {
+			return "", nil, nil, s.errorf(v, "se esperaba una variable")
+		}
+		if s.peek().kind != tCmp {
+			// "3 >= x"
+			val := lo
+			if op1.text == ">=" {
+				return v.text, nil, &val, nil
+			}
+			if op1.text == "=" {
+				return v.text, &val, &val, nil
+			}
+			return v.text, &val, nil, nil
+		}
+		op2 := s.next()
+		if op2.text != op1.text || op1.text == "=" {
+			return "", nil, nil, s.errorf(op2, "un rango necesita dos comparaciones en el mismo sentido")
+		}
+		hi, err := s.constant()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if op1.text == ">=" {
+			lo, hi = hi, lo
+		}
+		return v.text, &lo, &hi, nil
+	}
+	v := s.next()
+	if v.kind != tIdent {
+		return "", nil, nil, s.errorf(v, "se esperaba una variable")
+	}
+	op := s.next()
+	if op.kind != tCmp {
+		return "", nil, nil, s.errorf(op, "se esperaba un operador de comparación")
+	}
+	val, err := s.constant()
+	if err != nil {
+		return "", nil, nil, err
+	}
+	switch op.text {
+	case "<=":
+		return v.text, nil, &val, nil
+	case ">=":
+		return v.text, &val, nil, nil
+	}
+	return v.text, &val, &val, nil
+}
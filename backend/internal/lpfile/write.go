@@ -0,0 +1,170 @@
+package lpfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"autosimplex/internal/models"
+)
+
+// Write escribe req en formato LP. Se usan los nombres de la solicitud cuando son
+// nombres LP válidos y no se repiten; si no, las variables se llaman x1..xn (sin
+// chocar con los demás nombres) y las restricciones c1..cm. Las filas de rango se escriben como lo <= expr <= hi.
+func Write(w io.Writer, req models.SimplexRequest) error {
+	n := req.Objective.N
+	cols := req.Constraints.Cols
+	if n <= 0 || cols != n+1 || len(req.Objective.Coefficients) != n || len(req.Constraints.Vars) != req.Constraints.Rows*cols {
+		return fmt.Errorf("la solicitud no tiene dimensiones consistentes")
+	}
+	// Primero se reservan los nombres válidos de la solicitud; los reemplazos
+	// x1..xn que choquen con ellos (o entre sí) llevan un "_" más
+	names := make([]string, n)
+	used := map[string]bool{}
+	for j := range names {
+		if j < len(req.Objective.Names) && validName(req.Objective.Names[j]) && !used[req.Objective.Names[j]] {
+			names[j] = req.Objective.Names[j]
+			used[names[j]] = true
+		}
+	}
+	for j := range names {
+		if names[j] != "" {
+			continue
+		}
+		name := fmt.Sprintf("x%d", j+1)
+		for used[name] {
+			name += "_"
+		}
+		names[j] = name
+		used[name] = true
+	}
+	// Sin nombres de restricciones se numeran; si hay nombres, las filas sin
+	// nombre (por ejemplo, las que vienen de cotas) se escriben sin etiqueta y
+	// las que tienen un nombre que no se puede escribir pasan a c1..cm
+	labels := make([]string, req.Constraints.Rows)
+	if req.Constraints.Names != nil {
+		rowUsed := map[string]bool{}
+		for i := range labels {
+			if i < len(req.Constraints.Names) && validName(req.Constraints.Names[i]) && !rowUsed[req.Constraints.Names[i]] {
+				labels[i] = req.Constraints.Names[i]
+				rowUsed[labels[i]] = true
+			}
+		}
+		for i := range labels {
+			if labels[i] != "" || i >= len(req.Constraints.Names) || req.Constraints.Names[i] == "" {
+				continue
+			}
+			name := fmt.Sprintf("c%d", i+1)
+			for rowUsed[name] {
+				name += "_"
+			}
+			labels[i] = name
+			rowUsed[name] = true
+		}
+	} else {
+		for i := range labels {
+			labels[i] = fmt.Sprintf("c%d", i+1)
+		}
+	}
+	rowLabel := func(i int) string {
+		if labels[i] == "" {
+			return ""
+		}
+		return " " + labels[i] + ":"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, `\ Modelo generado por autosimplex`)
+	if strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize") {
+		fmt.Fprintln(bw, "Minimize")
+	} else {
+		fmt.Fprintln(bw, "Maximize")
+	}
+	fmt.Fprintf(bw, " obj: %s\n", expression(req.Objective.Coefficients, names))
+
+	fmt.Fprintln(bw, "Subject To")
+	for i := range req.Constraints.Rows {
+		row := req.Constraints.Vars[i*cols : (i+1)*cols]
+		expr := expression(row[:n], names)
+		rhs := formatNumber(row[n])
+		sign := "<="
+		if i < len(req.Constraints.Signs) {
+			sign = req.Constraints.Signs[i]
+		}
+		switch sign {
+		case "range":
+			lo := 0.0
+			if i < len(req.Constraints.Lower) {
+				lo = req.Constraints.Lower[i]
+			}
+			fmt.Fprintf(bw, "%s %s <= %s <= %s\n", rowLabel(i), formatNumber(lo), expr, rhs)
+		case ">=", "=":
+			fmt.Fprintf(bw, "%s %s %s %s\n", rowLabel(i), expr, sign, rhs)
+		default:
+			fmt.Fprintf(bw, "%s %s <= %s\n", rowLabel(i), expr, rhs)
+		}
+	}
+
+	if len(req.Integers) > 0 {
+		fmt.Fprintln(bw, "General")
+		var ints []string
+		for _, j := range req.Integers {
+			if j >= 0 && j < n {
+				ints = append(ints, names[j])
+			}
+		}
+		fmt.Fprintf(bw, " %s\n", strings.Join(ints, " "))
+	}
+	fmt.Fprintln(bw, "End")
+	return bw.Flush()
+}
+
+// expression escribe "3 x1 - 2 x2 + x3", omitiendo coeficientes nulos.
+func expression(coefs []float64, names []string) string {
+	var b strings.Builder
+	for j, v := range coefs {
+		if v == 0 {
+			continue
+		}
+		switch {
+		case b.Len() == 0 && v < 0:
+			b.WriteString("- ")
+		case b.Len() > 0 && v < 0:
+			b.WriteString(" - ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		if abs := max(v, -v); abs != 1 {
+			b.WriteString(formatNumber(abs) + " ")
+		}
+		b.WriteString(names[j])
+	}
+	if b.Len() == 0 {
+		// LP no admite expresiones vacías
+		return "0 " + names[0]
+	}
+	return b.String()
+}
+
+// validName indica si s se puede escribir tal cual como nombre en un archivo LP.
+// Las palabras reservadas (en cualquier combinación de mayúsculas) no sirven: al
+// comienzo de una línea se leerían como una sección, y "free", "inf" e
+// "infinity" tienen otro significado dentro de las cotas.
+func validName(s string) bool {
+	if s == "" || reSection.MatchString(s) || strings.EqualFold(s, "free") || strings.EqualFold(s, "inf") || strings.EqualFold(s, "infinity") {
+		return false
+	}
+	for i, r := range s {
+		if !isNameRune(r) && (i == 0 || !(unicode.IsDigit(r) || r == '.')) {
+			return false
+		}
+	}
+	return true
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
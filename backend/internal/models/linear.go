@@ -0,0 +1,169 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// Term is a coefficient attached to a variable by name.
+type Term struct {
+	Var  string  `json:"var"`
+	Coef float64 `json:"coef"`
+}
+
+// Row is a named, sparse constraint. Sign is one of "<=", ">=", "=" or "range";
+// for "range" rows Lower holds lo in lo <= a·x <= RHS.
+type Row struct {
+	Name  string  `json:"name,omitempty"`
+	Terms []Term  `json:"terms"`
+	Sign  string  `json:"sign"`
+	RHS   float64 `json:"rhs"`
+	Lower float64 `json:"lower,omitempty"`
+}
+
+// Bound restricts a single variable to [Lower, Upper]. Use math.Inf(1) for a
+// missing upper bound.
+type Bound struct {
+	Var   string
+	Lower float64
+	Upper float64
+}
+
+// LinearModel is a model written with named variables and sparse rows, the
+// common shape produced by the text importers before it is flattened into the
+// positional SimplexRequest layout.
+type LinearModel struct {
+	// Sense is "maximize" or "minimize".
+	Sense     string
+	Objective []Term
+	Rows      []Row
+	Bounds    []Bound
+	Integers  []string
+	// Variables optionally fixes the column order; variables not listed here are
+	// appended in order of first appearance (objective, rows, bounds, integers).
+	Variables []string
+}
+
+// VariableOrder returns the column order used by ToRequest.
+func (m LinearModel) VariableOrder() []string {
+	order := append([]string{}, m.Variables...)
+	seen := make(map[string]bool, len(order))
+	for _, v := range order {
+		seen[v] = true
+	}
+	add := func(v string) {
+		if !seen[v] {
+			seen[v] = true
+			order = append(order, v)
+		}
+	}
+	for _, t := range m.Objective {
+		add(t.Var)
+	}
+	for _, r := range m.Rows {
+		for _, t := range r.Terms {
+			add(t.Var)
+		}
+	}
+	for _, b := range m.Bounds {
+		add(b.Var)
+	}
+	for _, v := range m.Integers {
+		add(v)
+	}
+	return order
+}
+
+// ToRequest flattens the model into a SimplexRequest. Bounds become extra rows
+// (a single "range" row when both ends are given) because the solver only
+// knows x >= 0; negative lower bounds and free variables are rejected. Variable
+// and row names are kept in Objective.Names and Constraints.Names; rows added
+// for bounds have no name.
+func (m LinearModel) ToRequest() (SimplexRequest, error) {
+	order := m.VariableOrder()
+	if len(order) == 0 {
+		return SimplexRequest{}, fmt.Errorf("el modelo no tiene variables")
+	}
+	index := make(map[string]int, len(order))
+	for i, v := range order {
+		index[v] = i
+	}
+	n := len(order)
+
+	req := SimplexRequest{Objective: Objective{N: n, Coefficients: make([]float64, n), Type: m.Sense, Names: order}}
+	for _, t := range m.Objective {
+		req.Objective.Coefficients[index[t.Var]] += t.Coef
+	}
+
+	rows := append([]Row{}, m.Rows...)
+	for _, b := range m.Bounds {
+		if _, ok := index[b.Var]; !ok {
+			return SimplexRequest{}, fmt.Errorf("cota sobre una variable desconocida: %s", b.Var)
+		}
+		if b.Lower < 0 {
+			return SimplexRequest{}, fmt.Errorf("la variable %s tiene cota inferior negativa, no soportada (todas las variables son >= 0)", b.Var)
+		}
+		if b.Upper < b.Lower {
+			return SimplexRequest{}, fmt.Errorf("la variable %s tiene cota superior menor que la inferior", b.Var)
+		}
+		term := []Term{{Var: b.Var, Coef: 1}}
+		hasUpper := !math.IsInf(b.Upper, 1)
+		switch {
+		case hasUpper && b.Lower > 0 && b.Lower == b.Upper:
+			rows = append(rows, Row{Terms: term, Sign: "=", RHS: b.Upper})
+		case hasUpper && b.Lower > 0:
+			rows = append(rows, Row{Terms: term, Sign: "range", RHS: b.Upper, Lower: b.Lower})
+		case hasUpper:
+			rows = append(rows, Row{Terms: term, Sign: "<=", RHS: b.Upper})
+		case b.Lower > 0:
+			rows = append(rows, Row{Terms: term, Sign: ">=", RHS: b.Lower})
+		}
+	}
+	if len(rows) == 0 {
+		return SimplexRequest{}, fmt.Errorf("el modelo no tiene restricciones")
+	}
+
+	cols := n + 1
+	req.Constraints = Constraints{Rows: len(rows), Cols: cols, Vars: make([]float64, len(rows)*cols)}
+	hasRange := false
+	for _, r := range rows {
+		if r.Sign == "range" {
+			hasRange = true
+		}
+	}
+	if hasRange {
+		req.Constraints.Lower = make([]float64, len(rows))
+	}
+	for i, r := range rows {
+		if r.Name == "" {
+			continue
+		}
+		if req.Constraints.Names == nil {
+			req.Constraints.Names = make([]string, len(rows))
+		}
+		req.Constraints.Names[i] = r.Name
+	}
+	for i, r := range rows {
+		for _, t := range r.Terms {
+			j, ok := index[t.Var]
+			if !ok {
+				return SimplexRequest{}, fmt.Errorf("variable desconocida: %s", t.Var)
+			}
+			req.Constraints.Vars[i*cols+j] += t.Coef
+		}
+		req.Constraints.Vars[i*cols+n] = r.RHS
+		req.Constraints.Signs = append(req.Constraints.Signs, r.Sign)
+		if r.Sign == "range" {
+			req.Constraints.Lower[i] = r.Lower
+		}
+	}
+
+	isInteger := make(map[string]bool, len(m.Integers))
+	for _, v := range m.Integers {
+		if !isInteger[v] {
+			isInteger[v] = true
+			req.Integers = append(req.Integers, index[v])
+		}
+	}
+	return req, nil
+}
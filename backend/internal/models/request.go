@@ -6,18 +6,32 @@ type Objective struct {
 	// Type indicates whether to "maximize" or "minimize" the objective.
 	// Optional: defaults to "maximize" when omitted.
 	Type string `json:"type,omitempty"`
+	// Names optionally labels the decision variables. When present it must have
+	// n entries; empty entries keep the default name (x1, x2, ...).
+	Names []string `json:"names,omitempty"`
 }
 
 type Constraints struct {
 	Rows int       `json:"rows"`
 	Cols int       `json:"cols"`
 	Vars []float64 `json:"vars"`
-	// Signs optionally holds the sense of each constraint: "<=", ">=", or "=".
-	// If omitted, all constraints are assumed to be "<=".
+	// Signs optionally holds the sense of each constraint: "<=", ">=", "=" or
+	// "range". If omitted, all constraints are assumed to be "<=".
 	Signs []string `json:"signs,omitempty"`
+	// Lower holds, for "range" rows, the lower bound lo of lo <= a·x <= b, where
+	// b is the row's last column. Entries for other rows are ignored.
+	Lower []float64 `json:"lower,omitempty"`
+	// Names optionally labels each constraint. When present it must have one
+	// entry per row; empty entries keep the default name. A named row "cap"
+	// gets auxiliary variables s_cap, e_cap or a_cap.
+	Names []string `json:"names,omitempty"`
 }
 
 type SimplexRequest struct {
 	Objective   Objective   `json:"objective"`
 	Constraints Constraints `json:"constraints"`
+	// Integers optionally lists the (0-based) decision variables declared as
+	// integer. The solver only handles the LP relaxation, so they are reported
+	// back but not enforced.
+	Integers []int `json:"integers,omitempty"`
 }
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ConstraintRow is the object form of a constraint. The left-hand side is given
+// either as dense Coefficients (one per variable, in objective order) or as
+// sparse Terms; Sign defaults to "<=" and Lower is the lower end of a "range" row.
+// Lower is a pointer so that a missing bound is not taken as 0.
+type ConstraintRow struct {
+	Name         string    `json:"name,omitempty"`
+	Coefficients []float64 `json:"coefficients,omitempty"`
+	Terms        []Term    `json:"terms,omitempty"`
+	Sign         string    `json:"sign,omitempty"`
+	RHS          float64   `json:"rhs"`
+	Lower        *float64  `json:"lower,omitempty"`
+}
+
+// MissingLowerError reports the "range" rows (by index) that were given in
+// object form without a lower bound. The positional form cannot represent such
+// a row, so decoding stops and the caller reports it as the request schema would.
+type MissingLowerError struct {
+	Rows []int
+}
+
+func (e *MissingLowerError) Error() string {
+	return fmt.Sprintf("falta la cota inferior de la restricción de rango %d", e.Rows[0])
+}
+
+// UnmarshalJSON accepts both request layouts. Besides the positional one
+// (rows/cols/vars), "constraints" may be an array of ConstraintRow and the
+// objective may list "terms" instead of n/coefficients. The object layout is
+// converted here into the positional one, so the rest of the program never
+// sees it and clients do not need to compute cols or interleave the RHS. Signs
+// are copied as given; the request schema is the one that checks them.
+func (r *SimplexRequest) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Objective struct {
+			Objective
+			Terms []Term `json:"terms"`
+		} `json:"objective"`
+		Constraints json.RawMessage `json:"constraints"`
+		Integers    []int           `json:"integers"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	obj, terms := raw.Objective.Objective, raw.Objective.Terms
+
+	list := bytes.TrimSpace(raw.Constraints)
+	if len(list) == 0 || list[0] != '[' {
+		if len(terms) > 0 {
+			return fmt.Errorf("los términos del objetivo requieren restricciones en forma de lista")
+		}
+		var cons Constraints
+		if len(list) > 0 && !bytes.Equal(list, []byte("null")) {
+			if err := json.Unmarshal(list, &cons); err != nil {
+				return err
+			}
+		}
+		*r = SimplexRequest{Objective: obj, Constraints: cons, Integers: raw.Integers}
+		return nil
+	}
+
+	var rows []ConstraintRow
+	if err := json.Unmarshal(list, &rows); err != nil {
+		return err
+	}
+	req, err := fromRows(obj, terms, rows)
+	if err != nil {
+		return err
+	}
+	req.Integers = raw.Integers
+	*r = req
+	return nil
+}
+
+// fromRows builds the positional request from the objective and the row objects.
+func fromRows(obj Objective, terms []Term, rows []ConstraintRow) (SimplexRequest, error) {
+	m := LinearModel{Sense: obj.Type, Objective: terms}
+	dense := len(terms) == 0
+	var names []string
+	if dense {
+		n := len(obj.Coefficients)
+		if obj.N != 0 && obj.N != n {
+			return SimplexRequest{}, fmt.Errorf("la cantidad de coeficientes (%d) no coincide con n (%d)", n, obj.N)
+		}
+		if len(obj.Names) > 0 && len(obj.Names) != n {
+			return SimplexRequest{}, fmt.Errorf("la cantidad de nombres de variables no coincide con la de coeficientes")
+		}
+		names = make([]string, n)
+		for j, v := range obj.Coefficients {
+			names[j] = fmt.Sprintf("x%d", j+1)
+			if j < len(obj.Names) && obj.Names[j] != "" {
+				names[j] = obj.Names[j]
+			}
+			m.Objective = append(m.Objective, Term{Var: names[j], Coef: v})
+		}
+		m.Variables = names
+	} else if len(obj.Coefficients) > 0 {
+		return SimplexRequest{}, fmt.Errorf("el objetivo debe usar coefficients o terms, no ambos")
+	}
+
+	// With a dense objective the variables are fixed; an unknown name is a typo
+	known := make(map[string]bool, len(names))
+	for _, v := range names {
+		known[v] = true
+	}
+	var missing []int
+	for i, row := range rows {
+		sign := row.Sign
+		if sign == "" {
+			sign = "<="
+		}
+		r := Row{Name: row.Name, Terms: row.Terms, Sign: sign, RHS: row.RHS}
+		if row.Lower != nil {
+			r.Lower = *row.Lower
+		} else if sign == "range" {
+			missing = append(missing, i)
+		}
+		if len(row.Coefficients) > 0 {
+			switch {
+			case len(row.Terms) > 0:
+				return SimplexRequest{}, fmt.Errorf("la restricción %d debe usar coefficients o terms, no ambos", i)
+			case !dense:
+				return SimplexRequest{}, fmt.Errorf("la restricción %d usa coefficients pero el objetivo se dio con terms", i)
+			case len(row.Coefficients) != len(names):
+				return SimplexRequest{}, fmt.Errorf("la restricción %d tiene %d coeficientes, se esperaban %d", i, len(row.Coefficients), len(names))
+			}
+			r.Terms = nil
+			for j, v := range row.Coefficients {
+				r.Terms = append(r.Terms, Term{Var: names[j], Coef: v})
+			}
+		}
+		if dense {
+			for _, t := range row.Terms {
+				if !known[t.Var] {
+					return SimplexRequest{}, fmt.Errorf("la restricción %d usa la variable desconocida %s", i, t.Var)
+				}
+			}
+		}
+		m.Rows = append(m.Rows, r)
+	}
+	if len(missing) > 0 {
+		return SimplexRequest{}, &MissingLowerError{Rows: missing}
+	}
+
+	req, err := m.ToRequest()
+	if err != nil {
+		return req, err
+	}
+	// Without explicit names keep the solver's default ones
+	if dense && len(obj.Names) == 0 {
+		req.Objective.Names = nil
+	}
+	return req, nil
+}
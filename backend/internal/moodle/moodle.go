@@ -0,0 +1,307 @@
+// Package moodle convierte un problema resuelto en un cuestionario en formato
+// Moodle XML, listo para importar en el banco de preguntas de un curso:
+//
+//   - preguntas numéricas con el valor óptimo y el de cada variable de decisión;
+//   - preguntas de opción múltiple con la variable que entra y la que sale de la
+//     base en cada iteración, mostrando el tableau de esa iteración.
+//
+// Todas las preguntas quedan en la categoría autosimplex del curso.
+package moodle
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// Tolerance es el error absoluto que se acepta en las respuestas numéricas.
+const Tolerance = 0.01
+
+// Category es la categoría del banco de preguntas donde se importan.
+const Category = "$course$/top/autosimplex"
+
+type quiz struct {
+	XMLName   xml.Name   `xml:"quiz"`
+	Questions []question `xml:"question"`
+}
+
+type question struct {
+	Type            string    `xml:"type,attr"`
+	Category        *plain    `xml:"category,omitempty"`
+	Name            *plain    `xml:"name,omitempty"`
+	QuestionText    *richText `xml:"questiontext,omitempty"`
+	GeneralFeedback *richText `xml:"generalfeedback,omitempty"`
+	DefaultGrade    string    `xml:"defaultgrade,omitempty"`
+	Penalty         string    `xml:"penalty,omitempty"`
+	Single          string    `xml:"single,omitempty"`
+	Shuffle         string    `xml:"shuffleanswers,omitempty"`
+	Numbering       string    `xml:"answernumbering,omitempty"`
+	Answers         []answer  `xml:"answer"`
+}
+
+type plain struct {
+	Text string `xml:"text"`
+}
+
+// richText es un texto HTML; va en CDATA para no escapar las etiquetas.
+type richText struct {
+	Format string `xml:"format,attr"`
+	Text   cdata  `xml:"text"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+type answer struct {
+	Fraction  string    `xml:"fraction,attr"`
+	Format    string    `xml:"format,attr,omitempty"`
+	Text      string    `xml:"text"`
+	Tolerance string    `xml:"tolerance,omitempty"`
+	Feedback  *richText `xml:"feedback,omitempty"`
+}
+
+func htmlText(s string) *richText {
+	return &richText{Format: "html", Text: cdata{s}}
+}
+
+// Write escribe en w el cuestionario de req y su resultado res.
+func Write(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+	names := decisionNames(req, res)
+	statement := model(req, names)
+	q := quiz{Questions: []question{{Type: "category", Category: &plain{Category}}}}
+
+	if res.Status == simplex.StatusOptimal {
+		q.Questions = append(q.Questions, numerical("Valor óptimo",
+			statement+"<p>¿Cuál es el valor óptimo de Z?</p>", res.OptimalValue))
+		for j, v := range res.Solution {
+			if j >= len(names) {
+				break
+			}
+			q.Questions = append(q.Questions, numerical("Valor de "+names[j],
+				statement+fmt.Sprintf("<p>¿Qué valor toma <b>%s</b> en la solución óptima?</p>", html.EscapeString(names[j])), v))
+		}
+	}
+
+	// Al minimizar entra la variable con el cj − zj más negativo (ver
+	// simplex.SolveProblem)
+	rule := "Entra la variable no básica con el mayor c<sub>j</sub> − z<sub>j</sub> positivo."
+	if strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize") {
+		rule = "Entra la variable no básica con el c<sub>j</sub> − z<sub>j</sub> más negativo."
+	}
+	for _, st := range res.Steps {
+		if st.EnteringLabel == "" {
+			continue
+		}
+		table := tableau(st)
+		if len(st.NonBaseLabels) >= 2 {
+			q.Questions = append(q.Questions, choice(
+				fmt.Sprintf("Iteración %d: variable entrante", st.Iteration),
+				statement+fmt.Sprintf("<p>Tableau de la iteración %d:</p>", st.Iteration)+table+
+					"<p>¿Qué variable entra a la base?</p>",
+				st.NonBaseLabels, st.EnteringLabel, rule))
+		}
+		// Con un cambio de cota o un problema no acotado no sale ninguna variable
+		if st.LeavingLabel != "" && !st.BoundFlip && len(st.BaseLabels) >= 2 {
+			q.Questions = append(q.Questions, choice(
+				fmt.Sprintf("Iteración %d: variable saliente", st.Iteration),
+				statement+fmt.Sprintf("<p>Tableau de la iteración %d, donde entra <b>%s</b>:</p>",
+					st.Iteration, html.EscapeString(st.EnteringLabel))+table+
+					"<p>¿Qué variable sale de la base?</p>",
+				st.BaseLabels, st.LeavingLabel,
+				fmt.Sprintf("Sale la variable básica con el menor cociente R / a (t = %s).", simplex.FormatNumber(st.TValue))))
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(q); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func numerical(name, text string, want float64) question {
+	return question{
+		Type:         "numerical",
+		Name:         &plain{name},
+		QuestionText: htmlText(text),
+		DefaultGrade: "1",
+		Penalty:      "0.3333333",
+		Answers: []answer{{
+			Fraction:  "100",
+			Text:      answerNumber(want),
+			Tolerance: strconv.FormatFloat(Tolerance, 'f', -1, 64),
+			Feedback:  htmlText("La respuesta es " + simplex.FormatNumber(want) + "."),
+		}},
+	}
+}
+
+// choice arma una pregunta de opción múltiple con una sola respuesta correcta
+// entre options.
+func choice(name, text string, options []string, correct, feedback string) question {
+	q := question{
+		Type:            "multichoice",
+		Name:            &plain{name},
+		QuestionText:    htmlText(text),
+		GeneralFeedback: htmlText(feedback),
+		DefaultGrade:    "1",
+		Penalty:         "0.3333333",
+		Single:          "true",
+		Shuffle:         "true",
+		Numbering:       "abc",
+	}
+	for _, o := range options {
+		fraction := "0"
+		if o == correct {
+			fraction = "100"
+		}
+		q.Answers = append(q.Answers, answer{Fraction: fraction, Format: "html", Text: html.EscapeString(o)})
+	}
+	return q
+}
+
+// answerNumber escribe la respuesta numérica con punto decimal y signo ASCII,
+// que es lo que Moodle compara.
+func answerNumber(v float64) string {
+	r := math.Round(v*1e6) / 1e6
+	if r == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(r, 'f', -1, 64)
+}
+
+// decisionNames devuelve los nombres de las variables de decisión, del catálogo
+// del solver si está o de la solicitud.
+func decisionNames(req models.SimplexRequest, res simplex.Result) []string {
+	names := make([]string, req.Objective.N)
+	for j := range names {
+		names[j] = fmt.Sprintf("x%d", j+1)
+		if j < len(req.Objective.Names) && req.Objective.Names[j] != "" {
+			names[j] = req.Objective.Names[j]
+		}
+	}
+	for _, v := range res.Variables {
+		if v.Kind == simplex.Decision && v.Index <= len(names) {
+			names[v.Index-1] = v.Name
+		}
+	}
+	return names
+}
+
+// model escribe el enunciado común a todas las preguntas.
+func model(req models.SimplexRequest, names []string) string {
+	var b strings.Builder
+	sense := "Maximizar"
+	if strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize") {
+		sense = "Minimizar"
+	}
+	b.WriteString("<p>Resuelva con el método simplex:</p>\n<p>")
+	fmt.Fprintf(&b, "%s Z = %s<br>\nsujeto a<br>\n", sense, expression(req.Objective.Coefficients, names))
+	n, cols := req.Objective.N, req.Constraints.Cols
+	for i := range req.Constraints.Rows {
+		vals := req.Constraints.Vars[i*cols : (i+1)*cols]
+		lhs, rhs := expression(vals[:n], names), simplex.FormatNumber(vals[n])
+		sign := "<="
+		if i < len(req.Constraints.Signs) {
+			sign = req.Constraints.Signs[i]
+		}
+		switch sign {
+		case "range":
+			lo := 0.0
+			if i < len(req.Constraints.Lower) {
+				lo = req.Constraints.Lower[i]
+			}
+			fmt.Fprintf(&b, "&nbsp;&nbsp;%s ≤ %s ≤ %s<br>\n", simplex.FormatNumber(lo), lhs, rhs)
+		case ">=":
+			fmt.Fprintf(&b, "&nbsp;&nbsp;%s ≥ %s<br>\n", lhs, rhs)
+		case "=":
+			fmt.Fprintf(&b, "&nbsp;&nbsp;%s = %s<br>\n", lhs, rhs)
+		default:
+			fmt.Fprintf(&b, "&nbsp;&nbsp;%s ≤ %s<br>\n", lhs, rhs)
+		}
+	}
+	escaped := make([]string, len(names))
+	for j, name := range names {
+		escaped[j] = html.EscapeString(name)
+	}
+	fmt.Fprintf(&b, "&nbsp;&nbsp;%s ≥ 0</p>\n", strings.Join(escaped, ", "))
+	return b.String()
+}
+
+// expression escribe "3·x1 − x2 + 2·x3" en HTML, omitiendo coeficientes nulos.
+func expression(coefs []float64, names []string) string {
+	var b strings.Builder
+	for j, v := range coefs {
+		if simplex.FormatNumber(v) == "0" || j >= len(names) {
+			continue
+		}
+		switch {
+		case b.Len() == 0 && v < 0:
+			b.WriteString("−")
+		case b.Len() > 0 && v < 0:
+			b.WriteString(" − ")
+		case b.Len() > 0:
+			b.WriteString(" + ")
+		}
+		if abs := math.Abs(v); simplex.FormatNumber(abs) != "1" {
+			b.WriteString(simplex.FormatNumber(abs) + "·")
+		}
+		b.WriteString(html.EscapeString(names[j]))
+	}
+	if b.Len() == 0 {
+		return "0"
+	}
+	return b.String()
+}
+
+// tableau escribe la tabla de una iteración en HTML, sin marcar el pivote para
+// no revelar la respuesta.
+func tableau(st simplex.SimplexStep) string {
+	if len(st.Table) == 0 {
+		return ""
+	}
+	nVars := len(st.Table[0]) - 1
+	var b strings.Builder
+	b.WriteString(`<table border="1" cellpadding="4" style="border-collapse: collapse; text-align: center;">` + "\n")
+	b.WriteString("<tr><th>Base</th>")
+	for j := range nVars {
+		label := fmt.Sprintf("v%d", j+1)
+		if j < len(st.ColumnLabels) {
+			label = st.ColumnLabels[j]
+		}
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(label))
+	}
+	b.WriteString("<th>R</th></tr>\n")
+	for i, r := range st.Table {
+		base := ""
+		if i < len(st.BaseLabels) {
+			base = st.BaseLabels[i]
+		}
+		fmt.Fprintf(&b, "<tr><th>%s</th>", html.EscapeString(base))
+		for _, v := range r {
+			fmt.Fprintf(&b, "<td>%s</td>", simplex.FormatNumber(v))
+		}
+		b.WriteString("</tr>\n")
+	}
+	if delta := simplex.FormatValues(st.CjMinusZj, st.CjMinusZjM); len(delta) > 0 {
+		b.WriteString("<tr><th>c<sub>j</sub> − z<sub>j</sub></th>")
+		for _, d := range delta {
+			fmt.Fprintf(&b, "<td>%s</td>", d)
+		}
+		b.WriteString("<td></td></tr>\n")
+	}
+	b.WriteString("</table>\n")
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package moodle
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestWrite(t *testing.T) {
+	// max 3 x1 + 2 x2 s.a. x1 + x2 <= 4, x1 + 3 x2 <= 6: Z* = 12 con x1 = 4
+	req := models.SimplexRequest{
+		Objective: models.Objective{N: 2, Coefficients: []float64{3, 2}, Names: []string{"mesas", "sillas<a>"}},
+		Constraints: models.Constraints{
+			Rows: 2,
+			Cols: 3,
+			Vars: []float64{1, 1, 4, 1, 3, 6},
+		},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(2, 3, req.Constraints.Vars),
+		Signs:       []string{"<=", "<="},
+		VarNames:    req.Objective.Names,
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req, res))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var got quiz
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &got))
+	types := make([]string, len(got.Questions))
+	for i, q := range got.Questions {
+		types[i] = q.Type
+	}
+	// Categoría, Z* y dos variables, y entrante y saliente de la única iteración con pivote
+	assert.Equal(t, []string{"category", "numerical", "numerical", "numerical", "multichoice", "multichoice"}, types)
+	assert.Equal(t, Category, got.Questions[0].Category.Text)
+
+	z := got.Questions[1]
+	assert.Equal(t, "12", z.Answers[0].Text)
+	assert.Equal(t, "0.01", z.Answers[0].Tolerance)
+	assert.Contains(t, z.QuestionText.Text.Value, "Maximizar Z = 3·mesas + 2·sillas&lt;a&gt;")
+	assert.Equal(t, "4", got.Questions[2].Answers[0].Text)
+	assert.Equal(t, "0", got.Questions[3].Answers[0].Text)
+
+	entering := got.Questions[4]
+	assert.Equal(t, "Iteración 0: variable entrante", entering.Name.Text)
+	assert.Equal(t, "true", entering.Single)
+	correct := []string{}
+	for _, a := range entering.Answers {
+		if a.Fraction == "100" {
+			correct = append(correct, a.Text)
+		}
+	}
+	assert.Equal(t, []string{"mesas"}, correct)
+	assert.Len(t, entering.Answers, 2)
+	assert.Contains(t, entering.QuestionText.Text.Value, "<table")
+	assert.Contains(t, entering.GeneralFeedback.Text.Value, "mayor c<sub>j</sub> − z<sub>j</sub> positivo")
+
+	leaving := got.Questions[5]
+	for _, a := range leaving.Answers {
+		assert.Equal(t, a.Text == "s1", a.Fraction == "100", a.Text)
+	}
+}
+
+func TestWriteMinimize(t *testing.T) {
+	// min x1 + 2 x2 s.a. x1 + x2 >= 2: entra x1, con el cj − zj más negativo (1 − M)
+	req := models.SimplexRequest{
+		Objective:   models.Objective{N: 2, Coefficients: []float64{1, 2}, Type: "minimize"},
+		Constraints: models.Constraints{Rows: 1, Cols: 3, Vars: []float64{1, 1, 2}, Signs: []string{">="}},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(1, 3, req.Constraints.Vars),
+		Signs:       req.Constraints.Signs,
+		Minimize:    true,
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req, res))
+	var got quiz
+	assert.NoError(t, xml.Unmarshal(buf.Bytes(), &got))
+
+	var entering *question
+	for i, q := range got.Questions {
+		if q.Name != nil && q.Name.Text == "Iteración 0: variable entrante" {
+			entering = &got.Questions[i]
+		}
+	}
+	if assert.NotNil(t, entering) {
+		for _, a := range entering.Answers {
+			assert.Equal(t, a.Text == "x1", a.Fraction == "100", a.Text)
+		}
+		assert.Contains(t, entering.GeneralFeedback.Text.Value, "más negativo")
+		assert.NotContains(t, entering.GeneralFeedback.Text.Value, "positivo")
+	}
+}
+
+func TestWriteInfeasible(t *testing.T) {
+	// x1 <= 1 y x1 >= 2: no hay preguntas numéricas
+	req := models.SimplexRequest{
+		Objective:   models.Objective{N: 1, Coefficients: []float64{1}},
+		Constraints: models.Constraints{Rows: 2, Cols: 2, Vars: []float64{1, 1, 1, 2}, Signs: []string{"<=", ">="}},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(1, req.Objective.Coefficients),
+		Constraints: mat.NewDense(2, 2, req.Constraints.Vars),
+		Signs:       req.Constraints.Signs,
+	})
+	assert.Equal(t, simplex.StatusInfeasible, res.Status)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req, res))
+	assert.NotContains(t, buf.String(), `type="numerical"`)
+	assert.Contains(t, buf.String(), "≥ 2")
+}
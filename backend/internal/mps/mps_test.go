@@ -0,0 +1,122 @@
+package mps
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Mismo modelo que el de lpfile: rango en C3, cotas en X y Z, X entera.
+const fixedSample = `NAME          MEZCLA
+OBJSENSE
+    MAX
+ROWS
+ N  COSTO
+ L  C1
+ G  C2
+ E  C3
+COLUMNS
+    MARKER    'MARKER'                 'INTORG'
+    X         COSTO     3              C1        1
+    X         C2        2
+    MARKER    'MARKER'                 'INTEND'
+    Y         COSTO     2              C1        1
+    Y         C2        -1             C3        1
+    Z         COSTO     4              C1        1
+    Z         C3        1
+RHS
+    RHS       C1        10             C2        -2
+    RHS       C3        1
+RANGES
+    RNG       C3        5
+BOUNDS
+ UP BND       X         4
+ UP BND       Z         3
+ENDATA
+`
+
+// readers prueba ambos lectores: el libre también acepta columnas fijas sin espacios en los nombres.
+var readers = map[string]func(io.Reader) (models.SimplexRequest, error){
+	"libre": Read,
+	"fijo":  ReadFixed,
+}
+
+func TestRead(t *testing.T) {
+	for name, read := range readers {
+		req, err := read(strings.NewReader(fixedSample))
+		assert.NoError(t, err, name)
+
+		assert.Equal(t, "maximize", req.Objective.Type, name)
+		assert.Equal(t, []float64{3, 2, 4}, req.Objective.Coefficients, name)
+		// E con R > 0 es el rango [1, 6]; las cotas superiores se agregan como filas
+		assert.Equal(t, []string{"<=", ">=", "range", "<=", "<="}, req.Constraints.Signs, name)
+		assert.Equal(t, []float64{
+			1, 1, 1, 10,
+			2, -1, 0, -2,
+			0, 1, 1, 6,
+			1, 0, 0, 4,
+			0, 0, 1, 3,
+		}, req.Constraints.Vars, name)
+		if assert.Len(t, req.Constraints.Lower, 5, name) {
+			assert.Equal(t, 1.0, req.Constraints.Lower[2], name)
+		}
+		assert.Equal(t, []int{0}, req.Integers, name)
+	}
+}
+
+func TestReadFreeFormat(t *testing.T) {
+	// Formato libre sin nombre de conjunto, OBJSENSE en la cabecera y rangos sobre L y G
+	src := "NAME prueba\nOBJSENSE MIN\nROWS\n N obj\n L lim\n G pis\nCOLUMNS\n x obj 1 lim 1\n x pis 1\nRHS\n lim 8 pis 2\nRANGES\n lim 3 pis -4\nBOUNDS\n BV x\nENDATA\n"
+	m, err := ReadModel(strings.NewReader(src), false)
+	assert.NoError(t, err)
+	assert.Equal(t, "minimize", m.Sense)
+	assert.Equal(t, "range", m.Rows[0].Sign)
+	assert.Equal(t, 5.0, m.Rows[0].Lower)
+	assert.Equal(t, 8.0, m.Rows[0].RHS)
+	assert.Equal(t, 2.0, m.Rows[1].Lower)
+	assert.Equal(t, 6.0, m.Rows[1].RHS)
+	assert.Equal(t, []string{"x"}, m.Integers)
+	assert.Equal(t, 1.0, m.Bounds[0].Upper)
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	req, err := Read(strings.NewReader(fixedSample))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, req))
+	out := buf.String()
+	assert.Contains(t, out, "OBJSENSE\n    MAX\n")
+	assert.Contains(t, out, "'INTORG'")
+	assert.Contains(t, out, "    RNG       C3        5\n")
+
+	// MPS exige nombres de fila: las cotas, sin nombre, vuelven como C4 y C5
+	req.Constraints.Names[3], req.Constraints.Names[4] = "C4", "C5"
+	for name, read := range readers {
+		again, err := read(strings.NewReader(out))
+		assert.NoError(t, err, name)
+		assert.Equal(t, req, again, name)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	_, err := Read(strings.NewReader("ROWS\n N obj\n L c1\nCOLUMNS\n x obj 1 c2 1\nENDATA\n"))
+	var perr *parser.Error
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, 5, perr.Line)
+		assert.Equal(t, 10, perr.Column)
+	}
+
+	_, err = Read(strings.NewReader("ROWS\n N obj\n L c1\nCOLUMNS\n x obj 1 c1 1\nBOUNDS\n FR BND x\nENDATA\n"))
+	assert.Error(t, err)
+
+	_, err = Read(strings.NewReader("ROWS\n N obj\n L c1\nCOLUMNS\n x obj 1 c1 1\n"))
+	assert.ErrorContains(t, err, "ENDATA")
+}
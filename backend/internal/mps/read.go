@@ -0,0 +1,360 @@
+// Package mps lee y escribe modelos en formato MPS, tanto en columnas fijas como
+// en formato libre, incluidas las secciones RANGES y BOUNDS.
+package mps
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+)
+
+// Read lee un MPS en formato libre (campos separados por espacios), que también
+// acepta los archivos de columnas fijas cuyos nombres no tienen espacios.
+func Read(r io.Reader) (models.SimplexRequest, error) {
+	m, err := ReadModel(r, false)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
+
+// ReadFixed lee un MPS de columnas fijas, donde los nombres pueden tener espacios.
+func ReadFixed(r io.Reader) (models.SimplexRequest, error) {
+	m, err := ReadModel(r, true)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
+
+// field es un campo de una línea de datos con su columna (base 1).
+type field struct {
+	text string
+	col  int
+}
+
+// Posiciones (base 0) de los seis campos del formato fijo.
+var fixedFields = [][2]int{{1, 3}, {4, 12}, {14, 22}, {24, 36}, {39, 47}, {49, 61}}
+
+// rowInfo guarda el tipo y el lado derecho de cada fila mientras se lee.
+type rowInfo struct {
+	kind  byte // 'N', 'L', 'G' o 'E'
+	rhs   float64
+	rng   float64
+	isRng bool
+	terms []models.Term
+}
+
+// ReadModel lee un archivo MPS y devuelve el modelo con nombres, sin aplanarlo.
+func ReadModel(r io.Reader, fixed bool) (models.LinearModel, error) {
+	var m models.LinearModel
+	m.Sense = "minimize"
+
+	rows := map[string]*rowInfo{}
+	var rowOrder []string
+	objective := ""
+	var columns []string
+	colSeen := map[string]bool{}
+	bounds := map[string]*models.Bound{}
+	var boundOrder []string
+	integer := false
+	section := ""
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 1024*1024), 1024*1024)
+	lineNo := 0
+	for sc.Scan() {
+		lineNo++
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "*") {
+			continue
+		}
+		errAt := func(col int, format string, args ...any) error {
+			return &parser.Error{Line: lineNo, Column: col, Msg: fmt.Sprintf(format, args...)}
+		}
+
+		// Las cabeceras de sección empiezan en la columna 1
+		if !unicode.IsSpace(rune(line[0])) {
+			fs := strings.Fields(line)
+			section = strings.ToUpper(fs[0])
+			switch section {
+			case "NAME", "ROWS", "COLUMNS", "RHS", "RANGES", "BOUNDS", "OBJNAME":
+			case "OBJSENSE":
+				if len(fs) > 1 {
+					if err := setSense(&m, fs[1]); err != nil {
+						return m, errAt(len(fs[0])+2, "%s", err)
+					}
+				}
+			case "ENDATA":
+				return finish(m, rows, rowOrder, objective, columns, bounds, boundOrder)
+			default:
+				return m, errAt(1, "sección %q no soportada", fs[0])
+			}
+			continue
+		}
+
+		fs := splitFields(line, fixed)
+		if fixed && section != "ROWS" && section != "BOUNDS" && len(fs) > 0 {
+			// En formato fijo el primer campo (columnas 2-3) solo se usa para tipos
+			if fs[0].text != "" {
+				return m, errAt(fs[0].col, "campo inesperado %q en las columnas 2-3", fs[0].text)
+			}
+			fs = fs[1:]
+		}
+		if len(fs) == 0 {
+			continue
+		}
+		switch section {
+		case "OBJSENSE":
+			if err := setSense(&m, fs[0].text); err != nil {
+				return m, errAt(fs[0].col, "%s", err)
+			}
+		case "ROWS":
+			if len(fs) < 2 {
+				return m, errAt(fs[0].col, "se esperaba el tipo y el nombre de la fila")
+			}
+			kind := strings.ToUpper(fs[0].text)
+			if len(kind) != 1 || !strings.Contains("NLGE", kind) {
+				return m, errAt(fs[0].col, "tipo de fila %q inválido: use N, L, G o E", fs[0].text)
+			}
+			name := fs[1].text
+			if _, dup := rows[name]; dup {
+				return m, errAt(fs[1].col, "fila %q repetida", name)
+			}
+			if kind == "N" && objective != "" {
+				// Filas N adicionales: se ignoran, como hacen la mayoría de los solvers
+				rows[name] = &rowInfo{kind: 'X'}
+				continue
+			}
+			rows[name] = &rowInfo{kind: kind[0]}
+			if kind == "N" {
+				objective = name
+			} else {
+				rowOrder = append(rowOrder, name)
+			}
+		case "COLUMNS":
+			if len(fs) >= 3 && strings.Contains(strings.ToUpper(fs[1].text), "MARKER") {
+				last := fs[len(fs)-1]
+				switch strings.Trim(strings.ToUpper(last.text), "'") {
+				case "INTORG":
+					integer = true
+				case "INTEND":
+					integer = false
+				default:
+					return m, errAt(last.col, "marcador %q desconocido", last.text)
+				}
+				continue
+			}
+			if len(fs) != 3 && len(fs) != 5 {
+				return m, errAt(fs[0].col, "se esperaba columna, fila, valor [, fila, valor]")
+			}
+			col := fs[0].text
+			if !colSeen[col] {
+				colSeen[col] = true
+				columns = append(columns, col)
+				if integer {
+					m.Integers = append(m.Integers, col)
+				}
+			}
+			for k := 1; k+1 < len(fs); k += 2 {
+				row, ok := rows[fs[k].text]
+				if !ok {
+					return m, errAt(fs[k].col, "fila %q no declarada en ROWS", fs[k].text)
+				}
+				v, err := strconv.ParseFloat(fs[k+1].text, 64)
+				if err != nil {
+					return m, errAt(fs[k+1].col, "número inválido %q", fs[k+1].text)
+				}
+				if fs[k].text == objective {
+					m.Objective = append(m.Objective, models.Term{Var: col, Coef: v})
+				} else if row.kind != 'X' {
+					row.terms = append(row.terms, models.Term{Var: col, Coef: v})
+				}
+			}
+		case "RHS", "RANGES":
+			// El nombre del conjunto es opcional en formato libre: con una cantidad
+			// impar de campos el primero es el conjunto
+			pairs := fs
+			if fixed || len(fs)%2 == 1 {
+				pairs = fs[1:]
+			}
+			if len(pairs) != 2 && len(pairs) != 4 {
+				return m, errAt(fs[0].col, "se esperaba [conjunto] fila valor [fila valor]")
+			}
+			for k := 0; k+1 < len(pairs); k += 2 {
+				row, ok := rows[pairs[k].text]
+				if !ok {
+					return m, errAt(pairs[k].col, "fila %q no declarada en ROWS", pairs[k].text)
+				}
+				v, err := strconv.ParseFloat(pairs[k+1].text, 64)
+				if err != nil {
+					return m, errAt(pairs[k+1].col, "número inválido %q", pairs[k+1].text)
+				}
+				switch {
+				case section == "RHS" && pairs[k].text == objective:
+					if v != 0 {
+						return m, errAt(pairs[k].col, "constante en la función objetivo no soportada")
+					}
+				case section == "RHS":
+					row.rhs = v
+				case row.kind == 'N' || row.kind == 'X':
+					return m, errAt(pairs[k].col, "la fila %q no admite rango", pairs[k].text)
+				default:
+					row.rng, row.isRng = v, true
+				}
+			}
+		case "BOUNDS":
+			kind := strings.ToUpper(fs[0].text)
+			needsValue := kind == "UP" || kind == "LO" || kind == "FX" || kind == "LI" || kind == "UI"
+			rest := fs[1:]
+			// Sin valor: [conjunto] columna; con valor: [conjunto] columna valor
+			want := 1
+			if needsValue {
+				want = 2
+			}
+			if fixed || len(rest) > want {
+				if len(rest) == 0 {
+					return m, errAt(fs[0].col, "cota incompleta")
+				}
+				rest = rest[1:]
+			}
+			if len(rest) < want {
+				return m, errAt(fs[0].col, "cota incompleta")
+			}
+			col := rest[0].text
+			if !colSeen[col] {
+				return m, errAt(rest[0].col, "columna %q no declarada en COLUMNS", col)
+			}
+			val := 0.0
+			if needsValue {
+				v, err := strconv.ParseFloat(rest[1].text, 64)
+				if err != nil {
+					return m, errAt(rest[1].col, "número inválido %q", rest[1].text)
+				}
+				val = v
+			}
+			b, ok := bounds[col]
+			if !ok {
+				b = &models.Bound{Var: col, Lower: 0, Upper: math.Inf(1)}
+				bounds[col] = b
+				boundOrder = append(boundOrder, col)
+			}
+			switch kind {
+			case "UP", "UI":
+				b.Upper = val
+			case "LO", "LI":
+				b.Lower = val
+			case "FX":
+				b.Lower, b.Upper = val, val
+			case "FR", "MI":
+				b.Lower = math.Inf(-1)
+			case "PL":
+				b.Upper = math.Inf(1)
+			case "BV":
+				b.Lower, b.Upper = 0, 1
+			default:
+				return m, errAt(fs[0].col, "tipo de cota %q no soportado", fs[0].text)
+			}
+			if kind == "LI" || kind == "UI" || kind == "BV" {
+				m.Integers = append(m.Integers, col)
+			}
+			if b.Lower < 0 {
+				return m, errAt(fs[0].col, "cota inferior negativa o variable libre %s no soportada: todas las variables deben ser >= 0", col)
+			}
+		default:
+			return m, errAt(fs[0].col, "datos fuera de una sección")
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return m, err
+	}
+	return m, &parser.Error{Line: lineNo, Column: 1, Msg: "falta ENDATA"}
+}
+
+// setSense interpreta MAX/MAXIMIZE o MIN/MINIMIZE de OBJSENSE.
+func setSense(m *models.LinearModel, s string) error {
+	switch strings.ToUpper(s) {
+	case "MAX", "MAXIMIZE":
+		m.Sense = "maximize"
+	case "MIN", "MINIMIZE":
+		m.Sense = "minimize"
+	default:
+		return fmt.Errorf("sentido de optimización %q inválido", s)
+	}
+	return nil
+}
+
+// finish arma las filas del modelo a partir de ROWS, RHS y RANGES.
+func finish(m models.LinearModel, rows map[string]*rowInfo, order []string, objective string, columns []string, bounds map[string]*models.Bound, boundOrder []string) (models.LinearModel, error) {
+	if objective == "" {
+		return m, fmt.Errorf("el archivo no declara una fila objetivo (tipo N)")
+	}
+	m.Variables = columns
+	for _, name := range order {
+		ri := rows[name]
+		row := models.Row{Name: name, Terms: ri.terms, RHS: ri.rhs}
+		switch ri.kind {
+		case 'L':
+			row.Sign = "<="
+		case 'G':
+			row.Sign = ">="
+		case 'E':
+			row.Sign = "="
+		}
+		if ri.isRng {
+			// Semántica de RANGES: L [b-|R|, b], G [b, b+|R|], E según el signo de R
+			width := math.Abs(ri.rng)
+			switch {
+			case ri.kind == 'L' || (ri.kind == 'E' && ri.rng < 0):
+				row.Lower, row.RHS = ri.rhs-width, ri.rhs
+			default:
+				row.Lower, row.RHS = ri.rhs, ri.rhs+width
+			}
+			row.Sign = "range"
+		}
+		m.Rows = append(m.Rows, row)
+	}
+	for _, v := range boundOrder {
+		m.Bounds = append(m.Bounds, *bounds[v])
+	}
+	return m, nil
+}
+
+// splitFields separa una línea de datos en campos, por espacios o por columnas fijas.
+func splitFields(line string, fixed bool) []field {
+	var out []field
+	if !fixed {
+		rs := []rune(line)
+		for i := 0; i < len(rs); {
+			if unicode.IsSpace(rs[i]) {
+				i++
+				continue
+			}
+			start := i
+			for i < len(rs) && !unicode.IsSpace(rs[i]) {
+				i++
+			}
+			out = append(out, field{text: string(rs[start:i]), col: start + 1})
+		}
+		return out
+	}
+	for _, pos := range fixedFields {
+		if pos[0] >= len(line) {
+			break
+		}
+		end := min(pos[1], len(line))
+		out = append(out, field{text: strings.TrimSpace(line[pos[0]:end]), col: pos[0] + 1})
+	}
+	// Quitar campos vacíos al final; los del medio (conjunto RHS vacío) se conservan
+	for len(out) > 0 && out[len(out)-1].text == "" {
+		out = out[:len(out)-1]
+	}
+	return out
+}
@@ -0,0 +1,157 @@
+package mps
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"autosimplex/internal/models"
+)
+
+// Write escribe req en MPS con el diseño de columnas fijas. Las columnas y filas
+// usan los nombres de la solicitud y, si faltan o tienen espacios, X1..Xn y C1..Cm;
+// el objetivo es OBJ y las filas de rango se escriben con RANGES. Si un nombre o
+// un número no entra en su campo la línea sigue siendo MPS libre válido.
+func Write(w io.Writer, req models.SimplexRequest) error {
+	n := req.Objective.N
+	cols := req.Constraints.Cols
+	if n <= 0 || cols != n+1 || len(req.Objective.Coefficients) != n || len(req.Constraints.Vars) != req.Constraints.Rows*cols {
+		return fmt.Errorf("la solicitud no tiene dimensiones consistentes")
+	}
+	m := req.Constraints.Rows
+	signAt := func(i int) string {
+		if i < len(req.Constraints.Signs) {
+			return req.Constraints.Signs[i]
+		}
+		return "<="
+	}
+	// Filas y columnas tienen espacios de nombres separados
+	usedCols, usedRows := map[string]bool{}, map[string]bool{"OBJ": true}
+	colNames := make([]string, n)
+	for j := range n {
+		colNames[j] = uniqueName(usedCols, req.Objective.Names, j, "X")
+	}
+	rowNames := make([]string, m)
+	for i := range m {
+		rowNames[i] = uniqueName(usedRows, req.Constraints.Names, i, "C")
+	}
+	isInteger := make([]bool, n)
+	for _, j := range req.Integers {
+		if j >= 0 && j < n {
+			isInteger[j] = true
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "NAME          AUTOSIMPLEX")
+	fmt.Fprintln(bw, "OBJSENSE")
+	if strings.EqualFold(strings.TrimSpace(req.Objective.Type), "minimize") {
+		fmt.Fprintln(bw, "    MIN")
+	} else {
+		fmt.Fprintln(bw, "    MAX")
+	}
+
+	fmt.Fprintln(bw, "ROWS")
+	fmt.Fprintln(bw, " N  OBJ")
+	for i := range m {
+		kind := "L"
+		switch signAt(i) {
+		case ">=":
+			kind = "G"
+		case "=":
+			kind = "E"
+		}
+		fmt.Fprintf(bw, " %s  %s\n", kind, rowNames[i])
+	}
+
+	fmt.Fprintln(bw, "COLUMNS")
+	inMarker := false
+	for j := range n {
+		if isInteger[j] != inMarker {
+			marker := "INTORG"
+			if inMarker {
+				marker = "INTEND"
+			}
+			fmt.Fprintln(bw, line("", "MARKER", "'MARKER'", "", "'"+marker+"'"))
+			inMarker = isInteger[j]
+		}
+		col := colNames[j]
+		// Siempre se escribe el coeficiente del objetivo para que la columna exista
+		fmt.Fprintln(bw, line("", col, "OBJ", formatNumber(req.Objective.Coefficients[j])))
+		for i := range m {
+			if v := req.Constraints.Vars[i*cols+j]; v != 0 {
+				fmt.Fprintln(bw, line("", col, rowNames[i], formatNumber(v)))
+			}
+		}
+	}
+	if inMarker {
+		fmt.Fprintln(bw, line("", "MARKER", "'MARKER'", "", "'INTEND'"))
+	}
+
+	fmt.Fprintln(bw, "RHS")
+	for i := range m {
+		if v := req.Constraints.Vars[i*cols+n]; v != 0 {
+			fmt.Fprintln(bw, line("", "RHS", rowNames[i], formatNumber(v)))
+		}
+	}
+
+	// Una fila de rango lo <= a·x <= hi es una fila L con lado derecho hi y R = hi - lo
+	var ranges []string
+	for i := range m {
+		if signAt(i) != "range" {
+			continue
+		}
+		lo := 0.0
+		if i < len(req.Constraints.Lower) {
+			lo = req.Constraints.Lower[i]
+		}
+		ranges = append(ranges, line("", "RNG", rowNames[i], formatNumber(req.Constraints.Vars[i*cols+n]-lo)))
+	}
+	if len(ranges) > 0 {
+		fmt.Fprintln(bw, "RANGES")
+		for _, l := range ranges {
+			fmt.Fprintln(bw, l)
+		}
+	}
+	fmt.Fprintln(bw, "ENDATA")
+	return bw.Flush()
+}
+
+// line ubica los campos en las columnas del formato fijo (2, 5, 15, 25, 40).
+func line(fields ...string) string {
+	starts := []int{1, 4, 14, 24, 39}
+	var b strings.Builder
+	for k, f := range fields {
+		if f == "" {
+			continue
+		}
+		if pad := starts[k] - b.Len(); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		} else {
+			b.WriteString(" ")
+		}
+		b.WriteString(f)
+	}
+	return b.String()
+}
+
+// uniqueName devuelve names[i] si es un nombre MPS válido y libre, o prefix seguido
+// del número de la posición; en ambos casos evita repetir un nombre ya usado.
+func uniqueName(used map[string]bool, names []string, i int, prefix string) string {
+	name := fmt.Sprintf("%s%d", prefix, i+1)
+	if i < len(names) && names[i] != "" && !strings.ContainsFunc(names[i], unicode.IsSpace) && !strings.HasPrefix(names[i], "*") {
+		name = names[i]
+	}
+	for used[name] {
+		name += "_"
+	}
+	used[name] = true
+	return name
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error es un error de sintaxis con su posición en el texto (línea y columna base 1).
+type Error struct {
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Msg    string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("línea %d, columna %d: %s", e.Line, e.Column, e.Msg)
+}
+
+type tokenKind int
+
+const (
+	tNumber tokenKind = iota
+	tIdent
+	tPlus
+	tMinus
+	tStar
+	tCmp
+	tColon
+	tComma
+	tEOL
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	col  int
+}
+
+// lex divide una línea (ya sin comentarios) en tokens. Las columnas se cuentan en
+// runas, empezando en offset+1.
+func lex(line string, lineNo, offset int) ([]token, error) {
+	rs := []rune(line)
+	var toks []token
+	i := 0
+	for i < len(rs) {
+		r := rs[i]
+		col := offset + i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(rs) && unicode.IsDigit(rs[i+1])):
+			start := i
+			for i < len(rs) && (unicode.IsDigit(rs[i]) || rs[i] == '.') {
+				i++
+			}
+			// Exponente solo si le sigue un dígito: "2e3" es un número, "2e" es 2·e
+			if i < len(rs) && (rs[i] == 'e' || rs[i] == 'E') {
+				j := i + 1
+				if j < len(rs) && (rs[j] == '+' || rs[j] == '-') {
+					j++
+				}
+				if j < len(rs) && unicode.IsDigit(rs[j]) {
+					i = j
+					for i < len(rs) && unicode.IsDigit(rs[i]) {
+						i++
+					}
+				}
+			}
+			text := string(rs[start:i])
+			v, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, &Error{Line: lineNo, Column: col, Msg: fmt.Sprintf("número inválido %q", text)}
+			}
+			toks = append(toks, token{kind: tNumber, text: text, num: v, col: col})
+		case unicode.IsLetter(r) || r == '_':
+			// Los nombres pueden llevar índices entre corchetes: x[1,2]
+			start, depth := i, 0
+			for i < len(rs) && (isIdentRune(rs[i]) || (depth > 0 && rs[i] == ',')) {
+				switch rs[i] {
+				case '[':
+					depth++
+				case ']':
+					depth--
+				}
+				i++
+			}
+			toks = append(toks, token{kind: tIdent, text: string(rs[start:i]), col: col})
+		case r == '<' || r == '>' || r == '=':
+			text := string(r)
+			i++
+			if i < len(rs) && (rs[i] == '=' || (r == '=' && (rs[i] == '<' || rs[i] == '>'))) {
+				text += string(rs[i])
+				i++
+			}
+			toks = append(toks, token{kind: tCmp, text: normalizeCmp(text), col: col})
+		case r == '+':
+			toks = append(toks, token{kind: tPlus, text: "+", col: col})
+			i++
+		case r == '-' || r == '−':
+			toks = append(toks, token{kind: tMinus, text: "-", col: col})
+			i++
+		case r == '*' || r == '·':
+			toks = append(toks, token{kind: tStar, text: "*", col: col})
+			i++
+		case r == ':':
+			toks = append(toks, token{kind: tColon, text: ":", col: col})
+			i++
+		case r == ',':
+			toks = append(toks, token{kind: tComma, text: ",", col: col})
+			i++
+		case r == '≤' || r == '≥':
+			toks = append(toks, token{kind: tCmp, text: map[rune]string{'≤': "<=", '≥': ">="}[r], col: col})
+			i++
+		default:
+			return nil, &Error{Line: lineNo, Column: col, Msg: fmt.Sprintf("carácter inesperado %q", r)}
+		}
+	}
+	toks = append(toks, token{kind: tEOL, col: offset + len(rs) + 1})
+	return toks, nil
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '[' || r == ']' || r == '.'
+}
+
+// normalizeCmp lleva las variantes de comparación a "<=", ">=" o "=". Como en los
+// formatos LP, "<" y ">" se leen como "<=" y ">=".
+func normalizeCmp(s string) string {
+	switch s {
+	case "<", "<=", "=<":
+		return "<="
+	case ">", ">=", "=>":
+		return ">="
+	}
+	return "="
+}
+
+// stripComment elimina lo que sigue a "#" o "//".
+func stripComment(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	if i := strings.Index(line, "//"); i >= 0 {
+		line = line[:i]
+	}
+	return line
+}
@@ -0,0 +1,479 @@
+// Package parser lee modelos escritos a mano en notación algebraica, por ejemplo:
+//
+//	max z = 3x + 2y
+//	s.t.
+//	  capacidad: x + y <= 4
+//	  x + 3y <= 6
+//	  1 <= x - y <= 3
+//	bounds
+//	  y <= 3
+//	int x
+//
+// y los convierte en un models.SimplexRequest. Cada línea es una sentencia; los
+// comentarios empiezan con "#" o "//". Las secciones también pueden empezar en
+// medio de una línea, como en "max 3x + 2y s.t. x + y <= 4".
+package parser
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"autosimplex/internal/models"
+)
+
+// Parse lee el modelo de src y lo convierte en una solicitud para el solver.
+func Parse(src string) (models.SimplexRequest, error) {
+	m, err := ParseModel(src)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
+
+type section int
+
+const (
+	sObjective section = iota
+	sConstraints
+	sBounds
+	sEnd
+)
+
+// Palabras clave al comienzo de una línea. El orden importa: las formas largas
+// van antes que sus prefijos.
+var (
+	reObjective = regexp.MustCompile(`(?i)^\s*(maximize|maximise|maximizar|max|minimize|minimise|minimizar|min)\b\s*:?`)
+	reSubjectTo = regexp.MustCompile(`(?i)^\s*(subject\s+to|such\s+that|sujeto\s+a|s\.\s*t\.|st\s*:|st\s*$|s\.a\.)\s*:?`)
+	reBounds    = regexp.MustCompile(`(?i)^\s*(bounds|bound|cotas)\s*:?\s*$`)
+	reIntegers  = regexp.MustCompile(`(?i)^\s*(integers|integer|int|generals|general|gen|binaries|binary|bin)\b\s*:?`)
+	reEnd       = regexp.MustCompile(`(?i)^\s*end\s*$`)
+	// reInline encuentra las palabras clave de sección en medio de una línea,
+	// como en "max 3x + 2y s.t. x + y <= 4".
+	reInline = regexp.MustCompile(`(?i)\s(subject\s+to|such\s+that|sujeto\s+a|s\.\s*t\.|s\.a\.|st|bounds|bound|cotas|end)(\s*:)?(\s|$)`)
+)
+
+// ParseModel lee src y devuelve el modelo con variables y restricciones con nombre,
+// sin aplanarlo todavía.
+func ParseModel(src string) (models.LinearModel, error) {
+	p := &modelParser{bounds: map[string]*models.Bound{}}
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+lines:
+	for i, raw := range lines {
+		lineNo := i + 1
+		for _, text := range statements(stripComment(raw)) {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			if err := p.line(text, lineNo); err != nil {
+				return models.LinearModel{}, err
+			}
+			if p.section == sEnd {
+				break lines
+			}
+		}
+	}
+	if p.section == sObjective {
+		return models.LinearModel{}, &Error{Line: len(lines), Column: 1, Msg: "falta la función objetivo (max o min)"}
+	}
+	if len(p.model.Rows) == 0 && len(p.boundOrder) == 0 {
+		return models.LinearModel{}, &Error{Line: len(lines), Column: 1, Msg: "el modelo no tiene restricciones"}
+	}
+	for _, v := range p.boundOrder {
+		p.model.Bounds = append(p.model.Bounds, *p.bounds[v])
+	}
+	return p.model, nil
+}
+
+// statements parte la línea en sentencias en cada palabra clave de sección que
+// no está al comienzo: "max 3x + 2y s.t. x + y <= 4" son la función objetivo, la
+// sección de restricciones y una restricción. Cada parte conserva sus columnas
+// porque lo anterior se reemplaza por espacios.
+func statements(text string) []string {
+	var out []string
+	start := 0
+	for _, loc := range reInline.FindAllStringSubmatchIndex(text, -1) {
+		kwStart, kwEnd := loc[2], loc[3]
+		if loc[5] >= 0 {
+			kwEnd = loc[5]
+		}
+		if strings.TrimSpace(text[start:kwStart]) == "" {
+			continue
+		}
+		out = append(out, pad(text, start, kwStart), pad(text, kwStart, kwEnd))
+		start = kwEnd
+	}
+	return append(out, pad(text, start, len(text)))
+}
+
+// pad devuelve text[from:to] precedido por un espacio por cada carácter anterior.
+func pad(text string, from, to int) string {
+	return strings.Repeat(" ", runeCount(text[:from])) + text[from:to]
+}
+
+type modelParser struct {
+	model      models.LinearModel
+	section    section
+	bounds     map[string]*models.Bound
+	boundOrder []string
+}
+
+// line procesa una sentencia según la sección actual.
+func (p *modelParser) line(text string, lineNo int) error {
+	if p.section == sObjective {
+		loc := reObjective.FindStringSubmatchIndex(text)
+		if loc == nil {
+			return &Error{Line: lineNo, Column: firstColumn(text), Msg: "se esperaba la función objetivo: max o min seguido de una expresión"}
+		}
+		p.model.Sense = "maximize"
+		if strings.HasPrefix(strings.ToLower(text[loc[2]:loc[3]]), "min") {
+			p.model.Sense = "minimize"
+		}
+		p.section = sConstraints
+		return p.objective(text[loc[1]:], lineNo, runeCount(text[:loc[1]]))
+	}
+
+	if reEnd.MatchString(text) {
+		p.section = sEnd
+		return nil
+	}
+	if reBounds.MatchString(text) {
+		p.section = sBounds
+		return nil
+	}
+	if loc := reSubjectTo.FindStringIndex(text); loc != nil {
+		p.section = sConstraints
+		if strings.TrimSpace(text[loc[1]:]) == "" {
+			return nil
+		}
+		return p.constraint(text[loc[1]:], lineNo, runeCount(text[:loc[1]]))
+	}
+	// "int: x + y <= 4" es una restricción llamada int, no una sección de enteras
+	if loc := reIntegers.FindStringSubmatchIndex(text); loc != nil && !startsWithOperator(text[loc[1]:]) && !hasComparison(text[loc[1]:]) {
+		binary := strings.HasPrefix(strings.ToLower(text[loc[2]:loc[3]]), "bin")
+		return p.integers(text[loc[1]:], lineNo, runeCount(text[:loc[1]]), binary)
+	}
+	if p.section == sBounds {
+		return p.bound(text, lineNo)
+	}
+	return p.constraint(text, lineNo, 0)
+}
+
+// objective lee "[nombre =] expresión" después de max/min.
+func (p *modelParser) objective(text string, lineNo, offset int) error {
+	toks, err := lex(text, lineNo, offset)
+	if err != nil {
+		return err
+	}
+	// Nombre opcional de la función objetivo: "z = ..." o "z: ..."
+	if len(toks) > 2 && toks[0].kind == tIdent && (toks[1].kind == tColon || (toks[1].kind == tCmp && toks[1].text == "=")) {
+		toks = toks[2:]
+	}
+	s := &stream{toks: toks, line: lineNo}
+	e, err := s.expr()
+	if err != nil {
+		return err
+	}
+	if t := s.peek(); t.kind != tEOL {
+		return s.errorf(t, "se esperaba el fin de la función objetivo")
+	}
+	if len(e.terms) == 0 {
+		return &Error{Line: lineNo, Column: toks[0].col, Msg: "la función objetivo no tiene variables"}
+	}
+	if e.constant != 0 {
+		return &Error{Line: lineNo, Column: e.constCol, Msg: "la función objetivo no admite términos constantes"}
+	}
+	p.model.Objective = e.terms
+	return nil
+}
+
+// constraint lee "[nombre:] expr op expr [op expr]".
+func (p *modelParser) constraint(text string, lineNo, offset int) error {
+	toks, err := lex(text, lineNo, offset)
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(toks) > 2 && toks[0].kind == tIdent && toks[1].kind == tColon {
+		name = toks[0].text
+		toks = toks[2:]
+	}
+	row, err := relation(&stream{toks: toks, line: lineNo})
+	if err != nil {
+		return err
+	}
+	row.Name = name
+	p.model.Rows = append(p.model.Rows, row)
+	return nil
+}
+
+// bound lee una cota sobre una sola variable: "x <= 4", "x >= 1", "x = 2",
+// "1 <= x <= 4" o "x free".
+func (p *modelParser) bound(text string, lineNo int) error {
+	toks, err := lex(text, lineNo, 0)
+	if err != nil {
+		return err
+	}
+	if len(toks) == 3 && toks[0].kind == tIdent && toks[1].kind == tIdent && strings.EqualFold(toks[1].text, "free") {
+		return &Error{Line: lineNo, Column: toks[1].col, Msg: "variables libres no soportadas: todas las variables deben ser >= 0"}
+	}
+	row, err := relation(&stream{toks: toks, line: lineNo})
+	if err != nil {
+		return err
+	}
+	if len(row.Terms) != 1 || math.Abs(row.Terms[0].Coef) != 1 {
+		return &Error{Line: lineNo, Column: toks[0].col, Msg: "una cota debe involucrar una sola variable con coeficiente 1"}
+	}
+	v := row.Terms[0].Var
+	b, ok := p.bounds[v]
+	if !ok {
+		b = &models.Bound{Var: v, Lower: 0, Upper: math.Inf(1)}
+		p.bounds[v] = b
+		p.boundOrder = append(p.boundOrder, v)
+	}
+	if row.Terms[0].Coef < 0 {
+		// -x <= -4 equivale a x >= 4
+		row.RHS, row.Lower = -row.RHS, -row.Lower
+		switch row.Sign {
+		case "<=":
+			row.Sign = ">="
+		case ">=":
+			row.Sign = "<="
+		case "range":
+			row.RHS, row.Lower = row.Lower, row.RHS
+		}
+	}
+	switch row.Sign {
+	case "<=":
+		b.Upper = row.RHS
+	case ">=":
+		b.Lower = row.RHS
+	case "=":
+		b.Lower, b.Upper = row.RHS, row.RHS
+	case "range":
+		b.Lower, b.Upper = row.Lower, row.RHS
+	}
+	if b.Lower < 0 {
+		return &Error{Line: lineNo, Column: toks[0].col, Msg: fmt.Sprintf("cota inferior negativa para %s no soportada: todas las variables deben ser >= 0", v)}
+	}
+	if b.Upper < b.Lower {
+		return &Error{Line: lineNo, Column: toks[0].col, Msg: fmt.Sprintf("la cota superior de %s es menor que la inferior", v)}
+	}
+	return nil
+}
+
+// integers lee la lista de variables de "int x, y". Las binarias además quedan
+// acotadas por 1.
+func (p *modelParser) integers(text string, lineNo, offset int, binary bool) error {
+	toks, err := lex(text, lineNo, offset)
+	if err != nil {
+		return err
+	}
+	count := 0
+	for _, t := range toks {
+		switch t.kind {
+		case tIdent:
+			count++
+			p.model.Integers = append(p.model.Integers, t.text)
+			if binary {
+				if _, ok := p.bounds[t.text]; !ok {
+					p.boundOrder = append(p.boundOrder, t.text)
+				}
+				p.bounds[t.text] = &models.Bound{Var: t.text, Lower: 0, Upper: 1}
+			}
+		case tComma, tEOL:
+		default:
+			return &Error{Line: lineNo, Column: t.col, Msg: "se esperaba una lista de variables"}
+		}
+	}
+	if count == 0 {
+		return &Error{Line: lineNo, Column: toks[len(toks)-1].col, Msg: "se esperaba una lista de variables"}
+	}
+	return nil
+}
+
+// relation lee "expr op expr" o el rango "expr op expr op expr" y lo lleva a la
+// forma términos op constante.
+func relation(s *stream) (models.Row, error) {
+	first := s.peek()
+	exprs := []linExpr{}
+	ops := []token{}
+	for {
+		e, err := s.expr()
+		if err != nil {
+			return models.Row{}, err
+		}
+		exprs = append(exprs, e)
+		t := s.next()
+		if t.kind == tEOL {
+			break
+		}
+		if t.kind != tCmp {
+			return models.Row{}, s.errorf(t, "se esperaba un operador de comparación (<=, >=, =) o el fin de la línea")
+		}
+		if len(ops) == 2 {
+			return models.Row{}, s.errorf(t, "una restricción admite a lo sumo dos comparaciones")
+		}
+		ops = append(ops, t)
+	}
+
+	switch len(ops) {
+	case 0:
+		return models.Row{}, s.errorf(s.peek(), "se esperaba un operador de comparación (<=, >=, =)")
+	case 1:
+		// lhs op rhs  =>  (lhs - rhs) op 0
+		terms := combine(exprs[0].terms, exprs[1].terms, -1)
+		if len(terms) == 0 {
+			return models.Row{}, &Error{Line: s.line, Column: first.col, Msg: "la restricción no tiene variables"}
+		}
+		return models.Row{Terms: terms, Sign: ops[0].text, RHS: exprs[1].constant - exprs[0].constant}, nil
+	}
+
+	// lo <= expr <= hi (o hi >= expr >= lo)
+	if ops[0].text != ops[1].text || ops[0].text == "=" {
+		return models.Row{}, s.errorf(ops[1], "un rango necesita dos comparaciones en el mismo sentido (<= ... <= o >= ... >=)")
+	}
+	if len(exprs[0].terms) > 0 || len(exprs[2].terms) > 0 {
+		return models.Row{}, s.errorf(first, "en un rango solo la expresión del medio puede tener variables")
+	}
+	mid := exprs[1]
+	if len(mid.terms) == 0 {
+		return models.Row{}, &Error{Line: s.line, Column: first.col, Msg: "la restricción no tiene variables"}
+	}
+	lo, hi := exprs[0].constant-mid.constant, exprs[2].constant-mid.constant
+	if ops[0].text == ">=" {
+		lo, hi = hi, lo
+	}
+	if lo > hi {
+		return models.Row{}, &Error{Line: s.line, Column: first.col, Msg: "el límite inferior del rango supera al superior"}
+	}
+	return models.Row{Terms: mid.terms, Sign: "range", RHS: hi, Lower: lo}, nil
+}
+
+// linExpr es una expresión lineal: términos por variable y una constante.
+type linExpr struct {
+	terms    []models.Term
+	constant float64
+	constCol int
+}
+
+// stream recorre los tokens de una línea.
+type stream struct {
+	toks []token
+	pos  int
+	line int
+}
+
+func (s *stream) peek() token { return s.toks[s.pos] }
+
+func (s *stream) next() token {
+	t := s.toks[s.pos]
+	if t.kind != tEOL {
+		s.pos++
+	}
+	return t
+}
+
+func (s *stream) errorf(t token, format string, args ...any) *Error {
+	return &Error{Line: s.line, Column: t.col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// expr lee términos de la forma [+|-] [número] [*] [variable] hasta una
+// comparación o el fin de la línea.
+func (s *stream) expr() (linExpr, error) {
+	var e linExpr
+	index := map[string]int{}
+	first := true
+	for {
+		t := s.peek()
+		if t.kind == tCmp || t.kind == tEOL {
+			if first {
+				return e, s.errorf(t, "se esperaba un número o una variable")
+			}
+			return e, nil
+		}
+		sign := 1.0
+		sawSign := false
+		for t.kind == tPlus || t.kind == tMinus {
+			if t.kind == tMinus {
+				sign = -sign
+			}
+			sawSign = true
+			s.next()
+			t = s.peek()
+		}
+		if !first && !sawSign {
+			return e, s.errorf(t, "se esperaba + o - entre términos")
+		}
+
+		start := t
+		coef := 1.0
+		hasNumber := false
+		if t.kind == tNumber {
+			coef = t.num
+			hasNumber = true
+			s.next()
+			t = s.peek()
+			if t.kind == tStar {
+				s.next()
+				t = s.peek()
+				if t.kind != tIdent {
+					return e, s.errorf(t, "se esperaba una variable después de *")
+				}
+			}
+		}
+		switch {
+		case t.kind == tIdent:
+			s.next()
+			if j, ok := index[t.text]; ok {
+				e.terms[j].Coef += sign * coef
+			} else {
+				index[t.text] = len(e.terms)
+				e.terms = append(e.terms, models.Term{Var: t.text, Coef: sign * coef})
+			}
+		case hasNumber:
+			e.constant += sign * coef
+			e.constCol = start.col
+		default:
+			return e, s.errorf(t, "se esperaba un número o una variable")
+		}
+		first = false
+	}
+}
+
+// combine suma a y factor·b término a término, conservando el orden de aparición.
+func combine(a, b []models.Term, factor float64) []models.Term {
+	out := append([]models.Term{}, a...)
+	index := map[string]int{}
+	for i, t := range out {
+		index[t.Var] = i
+	}
+	for _, t := range b {
+		if j, ok := index[t.Var]; ok {
+			out[j].Coef += factor * t.Coef
+		} else {
+			index[t.Var] = len(out)
+			out = append(out, models.Term{Var: t.Var, Coef: factor * t.Coef})
+		}
+	}
+	return out
+}
+
+func startsWithOperator(s string) bool {
+	t := strings.TrimSpace(s)
+	return t != "" && strings.ContainsAny(t[:1], "+-*<>=")
+}
+
+// hasComparison indica si s tiene un operador de comparación.
+func hasComparison(s string) bool {
+	return strings.ContainsAny(s, "<>=≤≥")
+}
+
+func firstColumn(s string) int {
+	return runeCount(s) - runeCount(strings.TrimLeft(s, " \t")) + 1
+}
+
+func runeCount(s string) int {
+	return len([]rune(s))
+}
@@ -0,0 +1,114 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse_TextbookModel(t *testing.T) {
+	src := `# ejemplo de clase
+max z = 3x + 2y
+s.t.
+  capacidad: x + y <= 4
+  x + 3y <= 6
+  2 <= x - y + 1 <= 4
+bounds
+  y <= 3
+int x
+`
+	req, err := Parse(src)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "maximize", req.Objective.Type)
+	assert.Equal(t, 2, req.Objective.N)
+	assert.Equal(t, []float64{3, 2}, req.Objective.Coefficients)
+
+	// 3 restricciones + 1 cota
+	assert.Equal(t, 4, req.Constraints.Rows)
+	assert.Equal(t, 3, req.Constraints.Cols)
+	assert.Equal(t, []float64{
+		1, 1, 4,
+		1, 3, 6,
+		1, -1, 3,
+		0, 1, 3,
+	}, req.Constraints.Vars)
+	assert.Equal(t, []string{"<=", "<=", "range", "<="}, req.Constraints.Signs)
+	assert.Equal(t, 1.0, req.Constraints.Lower[2])
+	assert.Equal(t, []int{0}, req.Integers)
+}
+
+func TestParse_MovesTermsAcrossComparison(t *testing.T) {
+	req, err := Parse("minimize: 2 x1 + 3*x2\nsubject to\n x1 + 4 >= 10 - x2\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "minimize", req.Objective.Type)
+	assert.Equal(t, []float64{1, 1, 6}, req.Constraints.Vars)
+	assert.Equal(t, []string{">="}, req.Constraints.Signs)
+}
+
+func TestParse_ErrorPositions(t *testing.T) {
+	cases := []struct {
+		src    string
+		line   int
+		column int
+	}{
+		{"3x + 2y\n", 1, 1},                             // falta max/min
+		{"max 3x + 2y\nx + y <= 4 5\n", 2, 12},          // operador faltante
+		{"max 3x + 2y\nx + y 4\n", 2, 7},                // falta la comparación
+		{"max 3x + 2y\nx + y <= 4\nx $ y <= 2\n", 3, 3}, // carácter inválido
+		{"max 3x\nx <= 4\nbounds\nx >= -1\n", 4, 1},     // cota negativa
+		{"max 3x\nx <= 4\nbounds\nx + y <= 1\n", 4, 1},  // cota con dos variables
+	}
+	for _, tc := range cases {
+		_, err := Parse(tc.src)
+		var perr *Error
+		if assert.True(t, errors.As(err, &perr), "expected parse error for %q, got %v", tc.src, err) {
+			assert.Equal(t, tc.line, perr.Line, tc.src)
+			assert.Equal(t, tc.column, perr.Column, tc.src)
+		}
+	}
+}
+
+func TestParse_ConstraintNamedLikeSection(t *testing.T) {
+	src := "max x + y\nst\nint: x + y <= 4\ngen: x <= 3\nbin: y >= 1\nint x\n"
+	m, err := ParseModel(src)
+	assert.NoError(t, err)
+	names := make([]string, len(m.Rows))
+	for i, r := range m.Rows {
+		names[i] = r.Name
+	}
+	assert.Equal(t, []string{"int", "gen", "bin"}, names)
+
+	req, err := Parse(src)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0}, req.Integers)
+}
+
+func TestParse_OneLine(t *testing.T) {
+	// El ejemplo del enunciado, con las secciones en la misma línea
+	req, err := Parse("max 3x + 2y s.t. x + y <= 4")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{3, 2}, req.Objective.Coefficients)
+	assert.Equal(t, []float64{1, 1, 4}, req.Constraints.Vars)
+
+	m, err := ParseModel("min x + y subject to c1: x + y >= 2 bounds x <= 3 end x <= 1")
+	assert.NoError(t, err)
+	assert.Equal(t, "minimize", m.Sense)
+	if assert.Len(t, m.Rows, 1) {
+		assert.Equal(t, "c1", m.Rows[0].Name)
+	}
+	assert.Equal(t, 3.0, m.Bounds[0].Upper)
+
+	req, err = Parse("max x st x <= 4")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 4}, req.Constraints.Vars)
+
+	// Las columnas de los errores se cuentan desde el comienzo de la línea
+	_, err = Parse("max 3x + 2y s.t. x + y 4")
+	var perr *Error
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, 1, perr.Line)
+		assert.Equal(t, 24, perr.Column)
+	}
+}
@@ -1,9 +1,14 @@
 package pdf
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
+	"math"
+	"strings"
 
+	"autosimplex/internal/graphical"
+	"autosimplex/internal/models"
 	"autosimplex/internal/simplex"
 
 	"github.com/johnfercher/maroto/pkg/color"
@@ -12,10 +17,31 @@ import (
 	"github.com/johnfercher/maroto/pkg/props"
 )
 
-// GenerateSimplexPDF escribe en w un PDF sencillo con el valor óptimo y la solución
 // GenerateSimplexPDF escribe en w un PDF sencillo con el valor óptimo, la solución
 // y las tablas intermedias (steps)
 func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simplex.SimplexStep, w io.Writer) error {
+	return generate(w, optimalValue, solution, steps, nil, nil)
+}
+
+// section dibuja una parte opcional del PDF.
+type section func(pdf.Maroto) error
+
+// Write es el formato pdf de /process: empieza por la transformación a la forma
+// estándar y, además del resultado y las tablas, si el problema tiene dos o
+// tres variables agrega el método gráfico con el recorrido del simplex.
+func Write(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+	sf := simplex.Standardize(simplex.FromRequest(req))
+	intro := []section{func(mPdf pdf.Maroto) error { return standardFormSection(mPdf, sf) }}
+	var sections []section
+	if g, err := graphical.Trace(req, res); err == nil {
+		sections = append(sections, func(mPdf pdf.Maroto) error { return graphicalSection(mPdf, g) })
+	}
+	return generate(w, res.OptimalValue, res.Solution, res.Steps, intro, sections)
+}
+
+// generate arma el PDF; intro va después del título y sections después de la
+// solución y antes de las tablas.
+func generate(w io.Writer, optimalValue float64, solution []float64, steps []simplex.SimplexStep, intro, sections []section) error {
 	mPdf := pdf.NewMaroto(m.Portrait, m.A4)
 
 	mPdf.Row(20, func() {
@@ -24,22 +50,41 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 		})
 	})
 
+	for _, draw := range intro {
+		if err := draw(mPdf); err != nil {
+			return err
+		}
+	}
+
 	mPdf.Row(10, func() {
 		mPdf.Col(12, func() {
 			mPdf.Text(fmt.Sprintf("Valor óptimo: %.6f", optimalValue), props.Text{Top: 2, Align: "left", Size: 12})
 		})
 	})
 
-	// Lista de variables
+	// Lista de variables, con los nombres de las columnas del tableau si existen
+	var names []string
+	if len(steps) > 0 {
+		names = steps[len(steps)-1].ColumnLabels
+	}
 	for i, v := range solution {
-		idx := i + 1
+		name := fmt.Sprintf("x%d", i+1)
+		if i < len(names) {
+			name = names[i]
+		}
 		mPdf.Row(8, func() {
 			mPdf.Col(12, func() {
-				mPdf.Text(fmt.Sprintf("x%d = %.6f", idx, v), props.Text{Top: 2, Align: "left", Size: 11})
+				mPdf.Text(fmt.Sprintf("%s = %.6f", name, v), props.Text{Top: 2, Align: "left", Size: 11})
 			})
 		})
 	}
 
+	for _, draw := range sections {
+		if err := draw(mPdf); err != nil {
+			return err
+		}
+	}
+
 	// Tablas intermedias (steps)
 	if len(steps) > 0 {
 		mPdf.Row(12, func() {
@@ -60,8 +105,8 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 			// Encabezado: cj
 			if len(st.Cj) > 0 {
 				line := "cj:"
-				for _, v := range st.Cj {
-					line += fmt.Sprintf(" %8.2f", v)
+				for _, v := range formatValues(st.Cj, st.CjM) {
+					line += fmt.Sprintf(" %8s", v)
 				}
 				mPdf.Row(8, func() {
 					mPdf.Col(12, func() {
@@ -75,7 +120,11 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 			headers := []string{"c_b", "Base"}
 			if len(st.Table) > 0 {
 				for j := 0; j < len(st.Table[0])-1; j++ {
-					headers = append(headers, fmt.Sprintf("v%d", j+1))
+					if j < len(st.ColumnLabels) {
+						headers = append(headers, st.ColumnLabels[j])
+					} else {
+						headers = append(headers, fmt.Sprintf("v%d", j+1))
+					}
 				}
 			}
 			headers = append(headers, "R")
@@ -83,10 +132,12 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 			contents := [][]string{}
 			for rIdx, row := range st.Table {
 				cols := []string{"", ""}
-				if rIdx < len(st.Cb) {
-					cols[0] = fmt.Sprintf("%.2f", st.Cb[rIdx])
+				if cb := formatValues(st.Cb, st.CbM); rIdx < len(cb) {
+					cols[0] = cb[rIdx]
 				}
-				if rIdx < len(st.BaseVariables) {
+				if rIdx < len(st.BaseLabels) {
+					cols[1] = st.BaseLabels[rIdx]
+				} else if rIdx < len(st.BaseVariables) {
 					bv := st.BaseVariables[rIdx]
 					if bv <= len(solution) {
 						cols[1] = fmt.Sprintf("X%d", bv)
@@ -96,7 +147,7 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 				}
 				for c := 0; c < len(row)-1; c++ {
 					val := row[c]
-					cell := fmt.Sprintf("%.2f", val)
+					cell := trimFloat(val)
 					if rIdx == st.PivotRow && c == st.PivotCol {
 						cell = "▶" + cell + "◀"
 					}
@@ -104,13 +155,31 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 				}
 				// Lado derecho (RHS)
 				if len(row) > 0 {
-					cols = append(cols, fmt.Sprintf("%.2f", row[len(row)-1]))
+					cols = append(cols, trimFloat(row[len(row)-1]))
 				} else {
 					cols = append(cols, "")
 				}
 				contents = append(contents, cols)
 			}
 
+			// Filas Zj y Cj - Zj; la columna R de Zj muestra el valor Z de la iteración
+			if len(st.Zj) > 0 {
+				zRow := []string{"", "Zj"}
+				zRow = append(zRow, formatValues(st.Zj, st.ZjM)...)
+				if st.ObjectiveValueM != nil {
+					zRow = append(zRow, symbolic(*st.ObjectiveValueM))
+				} else {
+					zRow = append(zRow, trimFloat(st.ObjectiveValue))
+				}
+				contents = append(contents, zRow)
+			}
+			if len(st.CjMinusZj) > 0 {
+				dRow := []string{"", "Cj-Zj"}
+				dRow = append(dRow, formatValues(st.CjMinusZj, st.CjMinusZjM)...)
+				dRow = append(dRow, "")
+				contents = append(contents, dRow)
+			}
+
 			// Renderizar tabla manualmente para permitir fondo de celda pivote
 			// Construir tamaños de cuadrícula (suma a 12)
 			nCols := len(headers)
@@ -188,10 +257,11 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 			// deshabilitar bordes después de dibujar tabla
 			mPdf.SetBorder(false)
 
-			// Línea de resumen: entrante / saliente / t
+			// Línea de resumen: entrante / saliente / t, o el veredicto en la última tabla
+			summary := stepSummary(st)
 			mPdf.Row(8, func() {
 				mPdf.Col(12, func() {
-					mPdf.Text(fmt.Sprintf("Entra: %d   Sale: %d   t: %.6f", st.EnteringVar, st.LeavingVar, st.TValue), props.Text{Top: 2, Align: "left", Size: 10})
+					mPdf.Text(summary, props.Text{Top: 2, Align: "left", Size: 10})
 				})
 			})
 		}
@@ -208,4 +278,212 @@ func GenerateSimplexPDF(optimalValue float64, solution []float64, steps []simple
 	return err
 }
 
-// funciones auxiliares eliminadas (no usadas)
+// formatValues formatea una fila del tableau como los demás informes, con el
+// guion ASCII (ver symbolic).
+func formatValues(values []float64, sym []simplex.MValue) []string {
+	out := simplex.FormatValues(values, sym)
+	for i := range out {
+		out[i] = strings.ReplaceAll(out[i], "−", "-")
+	}
+	return out
+}
+
+// symbolic formatea v como "3 - 2M"; la fuente del PDF no tiene el signo menos
+// tipográfico, así que se usa el guion ASCII.
+func symbolic(v simplex.MValue) string {
+	return strings.ReplaceAll(v.String(), "−", "-")
+}
+
+// stepSummary describe el pivote de una iteración o, en la última, el veredicto.
+func stepSummary(st simplex.SimplexStep) string {
+	entering, leaving := fmt.Sprint(st.EnteringVar), fmt.Sprint(st.LeavingVar)
+	if st.EnteringLabel != "" {
+		entering = st.EnteringLabel
+	}
+	if st.LeavingLabel != "" {
+		leaving = st.LeavingLabel
+	}
+	if st.BoundFlip {
+		return fmt.Sprintf("Entra: %s   llega a su cota superior (t: %.6f), se reemplaza por su complemento y la base no cambia", entering, st.TValue)
+	}
+	switch st.Status {
+	case simplex.StatusOptimal:
+		return fmt.Sprintf("Tabla óptima. Z = %.6f", st.ObjectiveValue)
+	case simplex.StatusInfeasible:
+		return "Tabla final: quedan variables artificiales positivas en la base, problema infactible"
+	case simplex.StatusUnbounded:
+		return fmt.Sprintf("Entra: %s   la columna no tiene elementos positivos, problema no acotado", entering)
+	}
+	return fmt.Sprintf("Entra: %s   Sale: %s   t: %.6f", entering, leaving, st.TValue)
+}
+
+// graphicalSection dibuja la región factible de un problema de dos o tres
+// variables y describe debajo cada recta (o plano) y el vértice óptimo.
+func graphicalSection(mPdf pdf.Maroto, g graphical.Geometry) error {
+	img, err := graphical.PNG(g)
+	if err != nil {
+		return err
+	}
+	mPdf.Row(12, func() {
+		mPdf.Col(12, func() {
+			mPdf.Text("Método gráfico:", props.Text{Top: 2, Align: "left", Size: 14})
+		})
+	})
+	mPdf.Row(110, func() {
+		mPdf.Col(12, func() {
+			err = mPdf.Base64Image(base64.StdEncoding.EncodeToString(img), m.Png, props.Rect{Center: true, Percent: 100})
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, len(g.Lines)+1)
+	for _, l := range g.Lines {
+		lines = append(lines, fmt.Sprintf("%s: %s %s %s", l.Name, lineExpression(l.A, g.Names), l.Sign, trimFloat(l.B)))
+	}
+	if s := g.Solid; s != nil {
+		for _, p := range s.Planes {
+			lines = append(lines, fmt.Sprintf("%s: %s %s %s", p.Name, expression(p.A[:], s.Names[:]), p.Sign, trimFloat(p.B)))
+		}
+	}
+	switch {
+	case g.Status == "optimal" && g.Solid != nil:
+		o := g.Solid.Optimum
+		lines = append(lines, fmt.Sprintf("Vértice óptimo: (%s, %s, %s), Z = %s", trimFloat(o.X), trimFloat(o.Y), trimFloat(o.Z), trimFloat(o.Value)))
+		if g.Alternative {
+			lines = append(lines, "Hay óptimos alternativos sobre una arista o una cara del poliedro.")
+		}
+	case g.Status == "optimal":
+		lines = append(lines, fmt.Sprintf("Vértice óptimo: (%s, %s), Z = %s", trimFloat(g.Optimum.X), trimFloat(g.Optimum.Y), trimFloat(g.Optimum.Value)))
+		if g.Alternative {
+			lines = append(lines, "Hay óptimos alternativos a lo largo de un lado de la región.")
+		}
+	case g.Status == "infeasible":
+		lines = append(lines, "La región factible es vacía.")
+	case g.Status == "unbounded":
+		lines = append(lines, "La región no está acotada en la dirección en que mejora el objetivo.")
+	}
+	for _, line := range lines {
+		mPdf.Row(7, func() {
+			mPdf.Col(12, func() {
+				mPdf.Text(line, props.Text{Top: 1, Align: "left", Size: 10})
+			})
+		})
+	}
+	return nil
+}
+
+// lineExpression escribe a1·x1 + a2·x2 con los nombres de las variables.
+func lineExpression(a [2]float64, names [2]string) string {
+	return expression(a[:], names[:])
+}
+
+// expression escribe a1·x1 + ... + an·xn con los nombres de las variables,
+// omitiendo los términos nulos.
+func expression(a []float64, names []string) string {
+	var parts []string
+	for j, v := range a {
+		if v == 0 {
+			continue
+		}
+		term := names[j]
+		if v != 1 && v != -1 {
+			term = trimFloat(math.Abs(v)) + names[j]
+		}
+		switch {
+		case len(parts) == 0 && v < 0:
+			parts = append(parts, "-"+term)
+		case len(parts) == 0:
+			parts = append(parts, term)
+		case v < 0:
+			parts = append(parts, "- "+term)
+		default:
+			parts = append(parts, "+ "+term)
+		}
+	}
+	if len(parts) == 0 {
+		return "0"
+	}
+	return strings.Join(parts, " ")
+}
+
+// trimFloat formatea v sin ceros de más (como en las tablas), con guion ASCII.
+func trimFloat(v float64) string {
+	return strings.ReplaceAll(simplex.FormatNumber(v), "−", "-")
+}
+
+// standardFormSection explica la transformación de cada restricción y muestra el
+// problema en forma estándar con la base inicial.
+func standardFormSection(mPdf pdf.Maroto, sf simplex.StandardForm) error {
+	names := make([]string, len(sf.Variables))
+	decision := []string{}
+	for j, v := range sf.Variables {
+		names[j] = v.Name
+		if v.Kind == simplex.Decision {
+			decision = append(decision, v.Name)
+		}
+	}
+	sense := "Max"
+	if sf.Minimize {
+		sense = "Min"
+	}
+
+	mPdf.Row(12, func() {
+		mPdf.Col(12, func() {
+			mPdf.Text("Forma estándar:", props.Text{Top: 2, Align: "left", Size: 14})
+		})
+	})
+	var lines []string
+	for _, r := range sf.Rows {
+		original := fmt.Sprintf("%s: %s %s %s", r.Name, expression(r.Coefficients, decision), r.Sign, trimFloat(r.RHS))
+		if r.Lower != nil {
+			original = fmt.Sprintf("%s: %s <= %s <= %s", r.Name, trimFloat(*r.Lower), expression(r.Coefficients, decision), trimFloat(r.RHS))
+		}
+		lines = append(lines, original, "    "+strings.ReplaceAll(r.Explanation, "−", "-"))
+	}
+	lines = append(lines, "", fmt.Sprintf("%s Z = %s", sense, objectiveExpression(sf.Objective, names)), "s.a.")
+	for _, row := range sf.Table {
+		lines = append(lines, fmt.Sprintf("    %s = %s", expression(row[:len(row)-1], names), trimFloat(row[len(row)-1])))
+	}
+	lines = append(lines, "    "+strings.Join(names, ", ")+" >= 0")
+	basis := make([]string, len(sf.BasisLabels))
+	for i, l := range sf.BasisLabels {
+		basis[i] = l + " = " + trimFloat(sf.Values[i])
+	}
+	lines = append(lines, "", "Base inicial: "+strings.Join(basis, ", ")+"   Z = "+symbolic(sf.ObjectiveValue))
+
+	for _, line := range lines {
+		// Las explicaciones largas ocupan más de un renglón
+		height := 6.0 * math.Ceil(math.Max(1, float64(len([]rune(line))))/110)
+		mPdf.Row(height, func() {
+			mPdf.Col(12, func() {
+				mPdf.Text(line, props.Text{Top: 1, Align: "left", Size: 10})
+			})
+		})
+	}
+	return nil
+}
+
+// objectiveExpression escribe el objetivo con los cj simbólicos: 3x1 + 2x2 - Ma3.
+func objectiveExpression(c []simplex.MValue, names []string) string {
+	var parts []string
+	for j, v := range c {
+		coef := symbolic(v)
+		switch {
+		case coef == "0":
+			continue
+		case coef == "1":
+			coef = ""
+		case coef == "-1":
+			coef = "-"
+		case strings.Contains(coef, " "):
+			coef = "(" + coef + ")"
+		}
+		parts = append(parts, coef+names[j])
+	}
+	if len(parts) == 0 {
+		return "0"
+	}
+	return strings.ReplaceAll(strings.Join(parts, " + "), "+ -", "- ")
+}
@@ -10,11 +10,13 @@ import (
 	"testing"
 
 	"autosimplex/internal/handler"
+	"autosimplex/internal/models"
 	pdf "autosimplex/internal/pdf"
 	"autosimplex/internal/simplex"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
 )
 
 // TestProcessPDFIntegration carga el ejemplo de request y solicita el PDF al handler
@@ -68,6 +70,37 @@ func TestProcessPDFIntegration(t *testing.T) {
 	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
 }
 
+// TestWriteGraphical verifica que con dos o tres variables el PDF incluye la figura
+func TestWriteGraphical(t *testing.T) {
+	req := models.SimplexRequest{
+		Objective:   models.Objective{N: 2, Coefficients: []float64{3, 2}},
+		Constraints: models.Constraints{Rows: 2, Cols: 3, Vars: []float64{1, 1, 4, 1, 3, 6}},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(2, 3, req.Constraints.Vars),
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, pdf.Write(&buf, req, res))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF")))
+	assert.Contains(t, buf.String(), "/Subtype /Image")
+
+	// Con tres variables la figura es el poliedro
+	req.Objective = models.Objective{N: 3, Coefficients: []float64{2, 3, 1}}
+	req.Constraints = models.Constraints{Rows: 2, Cols: 4, Vars: []float64{1, 1, 1, 4, 1, 3, 0, 6}}
+	buf.Reset()
+	assert.NoError(t, pdf.Write(&buf, req, simplex.SolveProblem(simplex.FromRequest(req))))
+	assert.Contains(t, buf.String(), "/Subtype /Image")
+
+	// Con una sola variable no hay figura
+	req.Objective = models.Objective{N: 1, Coefficients: []float64{3}}
+	req.Constraints = models.Constraints{Rows: 1, Cols: 2, Vars: []float64{1, 4}}
+	buf.Reset()
+	assert.NoError(t, pdf.Write(&buf, req, simplex.Result{}))
+	assert.NotContains(t, buf.String(), "/Subtype /Image")
+}
+
 // helper para leer desde io.Reader a bytes
 func readAll(r io.Reader) ([]byte, error) {
 	var b bytes.Buffer
@@ -0,0 +1,111 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+
+	"autosimplex/internal/graphical"
+	"autosimplex/internal/htmlreport"
+	"autosimplex/internal/latex"
+	"autosimplex/internal/models"
+	"autosimplex/internal/moodle"
+	"autosimplex/internal/pdf"
+	"autosimplex/internal/simplex"
+	"autosimplex/internal/textreport"
+	"autosimplex/internal/trace"
+)
+
+// Formatos incluidos. El orden importa para los comodines del Accept: text/*
+// elige el primero de tipo text registrado.
+func init() {
+	Register(Renderer{
+		Name:        "json",
+		ContentType: "application/json; charset=utf-8",
+		MediaTypes:  []string{"application/json"},
+		Write:       writeJSON,
+	})
+	Register(Renderer{
+		Name:        "pdf",
+		ContentType: "application/pdf",
+		MediaTypes:  []string{"application/pdf"},
+		Filename:    "resultado_simplex.pdf",
+		Write:       pdf.Write,
+	})
+	Register(Renderer{
+		Name:        "html",
+		ContentType: "text/html; charset=utf-8",
+		MediaTypes:  []string{"text/html", "application/xhtml+xml"},
+		Filename:    "resultado_simplex.html",
+		// El navegador lo muestra directamente, también en el teléfono
+		Inline: true,
+		Write:  htmlreport.Write,
+	})
+	Register(Renderer{
+		Name:        "markdown",
+		ContentType: "text/markdown; charset=utf-8",
+		MediaTypes:  []string{"text/markdown", "text/x-markdown"},
+		Write:       textreport.WriteMarkdown,
+	})
+	Register(Renderer{
+		Name:        "text",
+		ContentType: "text/plain; charset=utf-8",
+		MediaTypes:  []string{"text/plain"},
+		Write:       textreport.WriteText,
+	})
+	Register(Renderer{
+		Name:        "latex",
+		ContentType: "application/x-tex; charset=utf-8",
+		MediaTypes:  []string{"application/x-tex", "text/x-tex", "application/x-latex"},
+		Filename:    "resultado_simplex.tex",
+		Write:       latex.Write,
+	})
+	// Son varios CSV (uno por tabla) dentro de un zip: quien pide text/csv
+	// espera un único CSV, así que ese tipo no se negocia
+	Register(Renderer{
+		Name:        "csv",
+		ContentType: "application/zip",
+		MediaTypes:  []string{"application/zip"},
+		Filename:    "iteraciones.zip",
+		Write:       trace.WriteCSV,
+	})
+	Register(Renderer{
+		Name:        "ndjson",
+		ContentType: "application/x-ndjson",
+		MediaTypes:  []string{"application/x-ndjson", "application/jsonl"},
+		Filename:    "iteraciones.ndjson",
+		Write:       trace.WriteNDJSON,
+	})
+	Register(Renderer{
+		Name:        "svg",
+		ContentType: "image/svg+xml",
+		MediaTypes:  []string{"image/svg+xml"},
+		Filename:    "metodo_grafico.svg",
+		Inline:      true,
+		Write:       graphical.Write,
+	})
+	Register(Renderer{
+		Name:        "moodle",
+		ContentType: "application/xml; charset=utf-8",
+		MediaTypes:  []string{"application/xml", "text/xml"},
+		Filename:    "cuestionario_moodle.xml",
+		Write:       moodle.Write,
+	})
+}
+
+// Response arma el cuerpo JSON de /process.
+func Response(req models.SimplexRequest, res simplex.Result) map[string]any {
+	return map[string]any{
+		"optimal_value": res.OptimalValue,
+		"solution":      res.Solution,
+		"values":        res.Values(),
+		"steps":         res.Steps,
+		"warning":       res.Warning,
+		"variables":     res.Variables,
+		"integers":      req.Integers,
+		"status":        res.Status,
+	}
+}
+
+func writeJSON(w io.Writer, req models.SimplexRequest, res simplex.Result) error {
+	return json.NewEncoder(w).Encode(Response(req, res))
+}
@@ -0,0 +1,148 @@
+// Package render es el registro de formatos de salida de /process. Cada formato
+// se elige por el parámetro ?format= o, si falta, por el encabezado Accept; se
+// pueden agregar formatos con Register sin tocar el handler.
+package render
+
+import (
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// Func escribe el resultado de resolver req en w.
+type Func func(w io.Writer, req models.SimplexRequest, res simplex.Result) error
+
+// Renderer describe un formato de salida.
+type Renderer struct {
+	// Name es el valor de ?format= (json, pdf, html...).
+	Name string
+	// ContentType es el Content-Type de la respuesta.
+	ContentType string
+	// MediaTypes son los tipos del encabezado Accept que elige este formato; el
+	// primero debería coincidir con ContentType.
+	MediaTypes []string
+	// Filename, si no está vacío, se envía en Content-Disposition.
+	Filename string
+	// Inline indica que el navegador debe mostrar la respuesta en lugar de descargarla.
+	Inline bool
+	Write  Func
+}
+
+// Disposition devuelve el valor de Content-Disposition, o "" si no hace falta.
+func (r Renderer) Disposition() string {
+	if r.Filename == "" {
+		return ""
+	}
+	if r.Inline {
+		return "inline; filename=" + r.Filename
+	}
+	return "attachment; filename=" + r.Filename
+}
+
+// Default es el formato que se usa si no se pide ninguno o se acepta cualquiera.
+const Default = "json"
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Renderer{}
+	// order conserva el orden de registro para resolver comodines como text/*
+	order []string
+)
+
+// Register agrega (o reemplaza) un formato.
+func Register(r Renderer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := registry[r.Name]; !ok {
+		order = append(order, r.Name)
+	}
+	registry[r.Name] = r
+}
+
+// Lookup busca un formato por nombre.
+func Lookup(name string) (Renderer, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	r, ok := registry[name]
+	return r, ok
+}
+
+// Names devuelve los nombres registrados en orden de registro.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]string(nil), order...)
+}
+
+// Negotiate elige el formato: primero por format (el parámetro ?format=) y si está
+// vacío por el encabezado Accept, respetando los pesos q. Un Accept vacío o */*
+// elige el formato por defecto. Devuelve false si nada de lo pedido está registrado.
+func Negotiate(format, accept string) (Renderer, bool) {
+	if format = strings.ToLower(strings.TrimSpace(format)); format != "" {
+		return Lookup(format)
+	}
+	if strings.TrimSpace(accept) == "" {
+		return Lookup(Default)
+	}
+	for _, media := range parseAccept(accept) {
+		if r, ok := match(media); ok {
+			return r, true
+		}
+	}
+	return Renderer{}, false
+}
+
+// match busca el primer formato (en orden de registro) que sirve el tipo pedido.
+func match(media string) (Renderer, bool) {
+	if media == "*/*" {
+		return Lookup(Default)
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, name := range order {
+		r := registry[name]
+		for _, t := range r.MediaTypes {
+			if t == media || (strings.HasSuffix(media, "/*") && strings.HasPrefix(t, strings.TrimSuffix(media, "*"))) {
+				return r, true
+			}
+		}
+	}
+	return Renderer{}, false
+}
+
+// parseAccept devuelve los tipos del encabezado Accept ordenados por q
+// descendente, sin los que tienen q=0.
+func parseAccept(accept string) []string {
+	type entry struct {
+		media string
+		q     float64
+	}
+	var entries []entry
+	for _, part := range strings.Split(accept, ",") {
+		media, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if v, err := strconv.ParseFloat(s, 64); err == nil {
+				q = v
+			}
+		}
+		if q > 0 {
+			entries = append(entries, entry{media, q})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.media
+	}
+	return out
+}
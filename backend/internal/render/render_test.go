@@ -0,0 +1,69 @@
+package render
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		format, accept, want string
+	}{
+		{"", "", "json"},
+		{"", "*/*", "json"},
+		{"PDF", "text/html", "pdf"},
+		{"", "text/html", "html"},
+		{"", "application/zip", "csv"},
+		{"", "text/csv, application/zip;q=0.5", "csv"},
+		{"", "application/zip;q=0.2, application/x-ndjson;q=0.7", "ndjson"},
+		{"", "text/*", "html"},
+		{"", "image/png, */*;q=0.1", "json"},
+	}
+	for _, tc := range cases {
+		r, ok := Negotiate(tc.format, tc.accept)
+		assert.True(t, ok, "%q %q", tc.format, tc.accept)
+		assert.Equal(t, tc.want, r.Name, "%q %q", tc.format, tc.accept)
+	}
+
+	_, ok := Negotiate("", "image/png, application/pdf;q=0")
+	assert.False(t, ok)
+	// El renderer csv entrega un zip, no un CSV
+	_, ok = Negotiate("", "text/csv")
+	assert.False(t, ok)
+	_, ok = Negotiate("docx", "")
+	assert.False(t, ok)
+}
+
+func TestRegister(t *testing.T) {
+	Register(Renderer{
+		Name:        "prueba",
+		ContentType: "application/x-prueba",
+		MediaTypes:  []string{"application/x-prueba"},
+		Filename:    "prueba.txt",
+		Write: func(w io.Writer, _ models.SimplexRequest, res simplex.Result) error {
+			_, err := io.WriteString(w, string(res.Status))
+			return err
+		},
+	})
+	defer func() {
+		mu.Lock()
+		delete(registry, "prueba")
+		order = order[:len(order)-1]
+		mu.Unlock()
+	}()
+
+	r, ok := Negotiate("", "application/x-prueba")
+	assert.True(t, ok)
+	assert.Equal(t, "attachment; filename=prueba.txt", r.Disposition())
+	assert.Contains(t, Names(), "prueba")
+
+	var buf bytes.Buffer
+	assert.NoError(t, r.Write(&buf, models.SimplexRequest{}, simplex.Result{Status: simplex.StatusOptimal}))
+	assert.Equal(t, string(simplex.StatusOptimal), buf.String())
+}
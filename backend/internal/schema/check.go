@@ -0,0 +1,202 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"autosimplex/internal/models"
+)
+
+// Check revisa las reglas de la solicitud decodificada que cruzan campos: largos
+// que dependen de n y de rows, valores finitos, cotas de las filas de rango y
+// nombres repetidos. Las rutas apuntan al documento en la forma en que llegó
+// (ver Form).
+func Check(req models.SimplexRequest, form Form) Errors {
+	var errs Errors
+	add := func(path, code, format string, args ...any) {
+		errs = append(errs, Issue{Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+	obj, cons := req.Objective, req.Constraints
+	n, rows, cols := obj.N, cons.Rows, cons.Cols
+
+	// Función objetivo
+	if n <= 0 {
+		add("/objective/n", "minimum", "La cantidad de variables de decisión debe ser mayor a 0")
+	} else if len(obj.Coefficients) != n {
+		add("/objective/coefficients", "length_mismatch", "La cantidad de coeficientes no coincide con n")
+	}
+	for i, v := range obj.Coefficients {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			add(pointer("objective", "coefficients", i), "not_finite", "Coeficiente inválido en la posición %d", i)
+		}
+	}
+	if obj.Type != "" {
+		lower := strings.ToLower(strings.TrimSpace(obj.Type))
+		if lower != "minimize" && lower != "maximize" {
+			add("/objective/type", "enum", "Tipo de objetivo inválido: use 'minimize' o 'maximize'")
+		}
+	}
+
+	// Matriz de restricciones. Los mínimos de rows y cols y los signos válidos
+	// son reglas del esquema: no se repiten acá
+	shapeOK := rows > 0 && cols > 0
+	// Cada fila tiene un coeficiente por variable y el lado derecho
+	if cols > 0 && n > 0 && cols != n+1 {
+		add("/constraints/cols", "length_mismatch", "La cantidad de columnas (%d) debe ser n + 1 (%d): un coeficiente por variable y el lado derecho", cols, n+1)
+	}
+	if shapeOK && len(cons.Vars) != rows*cols {
+		add("/constraints/vars", "length_mismatch", "La cantidad de variables no coincide con filas x columnas")
+		shapeOK = false
+	}
+	for i, v := range cons.Vars {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			add(pointer("constraints", "vars", i), "not_finite", "Valor inválido en la matriz de restricciones en la posición %d", i)
+		}
+	}
+	// Filas de rango lo <= a·x <= b
+	for i, s := range cons.Signs {
+		if s != "range" {
+			continue
+		}
+		switch {
+		case i >= len(cons.Lower):
+			errs = append(errs, missingLower(i))
+		case math.IsNaN(cons.Lower[i]) || math.IsInf(cons.Lower[i], 0):
+			add(pointer("constraints", "lower", i), "not_finite", "Cota inferior inválida en la restricción %d", i)
+		case shapeOK && i < rows && cons.Lower[i] > cons.Vars[i*cols+cols-1]:
+			add(pointer("constraints", "lower", i), "range_inverted", "La cota inferior de la restricción %d supera a la superior", i)
+		}
+	}
+
+	errs = append(errs, checkNames(n, rows, obj.Names, cons.Names)...)
+	return relocate(errs, form, cols)
+}
+
+// MissingLower informa las filas de rango sin cota inferior que el decodificador
+// rechazó (ver models.MissingLowerError) con el mismo problema que daría Check.
+func MissingLower(rows []int, form Form) Errors {
+	var errs Errors
+	for _, i := range rows {
+		errs = append(errs, missingLower(i))
+	}
+	return relocate(errs, form, 0)
+}
+
+func missingLower(i int) Issue {
+	return Issue{
+		Path:    pointer("constraints", "lower", i),
+		Code:    "missing_lower",
+		Message: fmt.Sprintf("Falta la cota inferior de la restricción de rango %d", i),
+	}
+}
+
+// relocate pasa las rutas de la forma posicional a la forma en que llegó la solicitud.
+func relocate(errs Errors, form Form, cols int) Errors {
+	for i := range errs {
+		switch form {
+		case RowObjects:
+			errs[i].Path = rowPath(errs[i].Path, cols)
+		case Imported:
+			errs[i].Path = ""
+		}
+	}
+	return errs
+}
+
+// Form es la forma en que llegó la solicitud, que decide a dónde apuntan las
+// rutas de Check. Las reglas se revisan siempre sobre la forma posicional, que
+// es la que recibe el solver.
+type Form int
+
+const (
+	// Matrix es el JSON posicional: constraints con rows, cols y vars.
+	Matrix Form = iota
+	// RowObjects es el JSON con constraints como lista de objetos.
+	RowObjects
+	// Imported es cualquier otra entrada (texto, LP, MPS, GMPL, planillas): no
+	// hay un documento JSON al que apuntar y las rutas quedan vacías.
+	Imported
+)
+
+// rowPath traduce una ruta de la forma posicional a la de objetos:
+// /constraints/lower/1 pasa a /constraints/1/lower y un valor de vars apunta a
+// la restricción que lo contiene, o a su rhs si es la última columna. Los campos
+// de la matriz completa (rows, cols, vars, names) apuntan a /constraints.
+func rowPath(path string, cols int) string {
+	rest, ok := strings.CutPrefix(path, "/constraints/")
+	if !ok {
+		return path
+	}
+	field, index, _ := strings.Cut(rest, "/")
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return "/constraints"
+	}
+	switch field {
+	case "vars":
+		if cols <= 0 {
+			return "/constraints"
+		}
+		if i%cols == cols-1 {
+			return pointer("constraints", i/cols, "rhs")
+		}
+		return pointer("constraints", i/cols)
+	case "signs":
+		return pointer("constraints", i, "sign")
+	case "lower":
+		return pointer("constraints", i, "lower")
+	case "names":
+		return pointer("constraints", i, "name")
+	}
+	return "/constraints"
+}
+
+// checkNames revisa los nombres de variables y restricciones: la cantidad, los
+// espacios en los extremos y las repeticiones. Un nombre como s1 no choca con
+// las auxiliares: el solver las renombra (ver simplex.AuxName).
+func checkNames(n, rows int, varNames, rowNames []string) Errors {
+	var errs Errors
+	add := func(path, code, format string, args ...any) {
+		errs = append(errs, Issue{Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+	if len(varNames) > 0 && len(varNames) != n {
+		add("/objective/names", "length_mismatch", "La cantidad de nombres de variables no coincide con n")
+	}
+	if len(rowNames) > 0 && len(rowNames) != rows {
+		add("/constraints/names", "length_mismatch", "La cantidad de nombres de restricciones no coincide con filas")
+	}
+
+	seen := make(map[string]bool, max(n, 0))
+	for j := range n {
+		name := fmt.Sprintf("x%d", j+1)
+		if j < len(varNames) && varNames[j] != "" {
+			name = varNames[j]
+		}
+		path := pointer("objective", "names", j)
+		switch {
+		case strings.TrimSpace(name) != name:
+			add(path, "surrounding_space", "El nombre de la variable %d no puede empezar ni terminar con espacios", j)
+		case seen[name]:
+			add(path, "duplicate_name", "Nombre de variable repetido: %s", name)
+		}
+		seen[name] = true
+	}
+
+	seenRows := make(map[string]bool, len(rowNames))
+	for i, name := range rowNames {
+		if name == "" {
+			continue
+		}
+		path := pointer("constraints", "names", i)
+		switch {
+		case strings.TrimSpace(name) != name:
+			add(path, "surrounding_space", "El nombre de la restricción %d no puede empezar ni terminar con espacios", i)
+		case seenRows[name]:
+			add(path, "duplicate_name", "Nombre de restricción repetido: %s", name)
+		}
+		seenRows[name] = true
+	}
+	return errs
+}
@@ -0,0 +1,105 @@
+// Package schema publica los JSON Schema (draft 2020-12) de la solicitud, la
+// respuesta y los errores de la API, y valida las solicitudes contra ellos.
+//
+// La validación tiene dos etapas: Validate revisa el cuerpo JSON crudo contra el
+// esquema (tipos, campos obligatorios, signos válidos) y Check revisa las reglas
+// que cruzan campos y que un esquema no puede expresar, como que vars tenga
+// rows x cols valores. Ambas devuelven todos los problemas juntos, cada uno con
+// un puntero JSON (RFC 6901) y un código estable.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Version es la versión vigente de los esquemas; cambia solo ante cambios
+// incompatibles en la forma de la solicitud o de la respuesta.
+const Version = "v1"
+
+//go:embed v1/*.json
+var files embed.FS
+
+// Nombres de los esquemas publicados.
+const (
+	Request  = "request"
+	Response = "response"
+	Error    = "error"
+)
+
+// Names devuelve los esquemas disponibles.
+func Names() []string {
+	return []string{Request, Response, Error}
+}
+
+// Document devuelve el esquema name de la versión dada tal como se publica.
+func Document(version, name string) ([]byte, bool) {
+	data, err := files.ReadFile(version + "/" + name + ".json")
+	return data, err == nil
+}
+
+// compiled guarda cada esquema de la versión vigente ya decodificado.
+var compiled = map[string]map[string]any{}
+
+func init() {
+	for _, name := range Names() {
+		data, _ := Document(Version, name)
+		var doc map[string]any
+		if err := json.Unmarshal(data, &doc); err != nil {
+			panic(fmt.Sprintf("schema: %s.json inválido: %v", name, err))
+		}
+		compiled[name] = doc
+	}
+}
+
+// Issue es un problema de validación.
+type Issue struct {
+	// Path es el puntero JSON al valor con problemas, como /constraints/signs/2;
+	// vacío si la solicitud no llegó como JSON (ver Form).
+	Path string `json:"path"`
+	// Code identifica el tipo de problema: type, required, enum, minimum,
+	// length_mismatch, range_inverted, duplicate_name, etc.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors reúne todos los problemas de una solicitud.
+type Errors []Issue
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, is := range e {
+		msgs[i] = is.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Merge agrega a e los problemas de more cuya ruta todavía no tiene ninguno, para
+// que un mismo valor no se informe dos veces (por ejemplo, una n negativa viola
+// el mínimo del esquema y la regla de Check).
+func (e Errors) Merge(more Errors) Errors {
+	seen := make(map[string]bool, len(e))
+	for _, is := range e {
+		seen[is.Path] = true
+	}
+	for _, is := range more {
+		if !seen[is.Path] {
+			e = append(e, is)
+		}
+	}
+	return e
+}
+
+// pointer arma un puntero JSON con los segmentos dados, escapando "~" y "/".
+func pointer(parts ...any) string {
+	var b strings.Builder
+	for _, p := range parts {
+		s := fmt.Sprint(p)
+		s = strings.ReplaceAll(s, "~", "~0")
+		s = strings.ReplaceAll(s, "/", "~1")
+		b.WriteString("/" + s)
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http/httptest"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/render"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestValidateRequest(t *testing.T) {
+	valid := []string{
+		`{"objective": {"n": 2, "coefficients": [3, 2], "type": "Maximize"},
+		  "constraints": {"rows": 1, "cols": 3, "vars": [1, 1, 4], "signs": ["range"], "lower": [1]}}`,
+		`{"objective": {"terms": [{"var": "x", "coef": 3}]},
+		  "constraints": [{"terms": [{"var": "x", "coef": 1}], "rhs": 4, "sign": ">="}], "integers": [0]}`,
+		`{"objective": {"n": 1.0, "coefficients": [1]}, "constraints": {"rows": 1, "cols": 2, "vars": [1, 2]}}`,
+	}
+	for _, src := range valid {
+		assert.Empty(t, Validate(Request, []byte(src)), src)
+	}
+
+	cases := []struct {
+		src   string
+		want  []Issue
+		first string
+	}{
+		{`[1]`, []Issue{{Path: "", Code: "type"}}, ""},
+		{`{"objective": {}}`, []Issue{{Path: "/constraints", Code: "required"}}, ""},
+		{`{"objective": {"n": 1.5, "coefficients": [1, "a"]}, "constraints": {"rows": 0, "cols": 2, "vars": [1, 2]}}`,
+			[]Issue{
+				{Path: "/constraints/rows", Code: "minimum"},
+				{Path: "/objective/coefficients/1", Code: "type"},
+				{Path: "/objective/n", Code: "type"},
+			}, ""},
+		// Con la forma de lista se informan los problemas de cada fila
+		{`{"objective": {"coefficients": [1]}, "constraints": [{"sign": "<"}, {"rhs": 1, "terms": [{"var": "", "coef": 1}]}]}`,
+			[]Issue{
+				{Path: "/constraints/0/rhs", Code: "required"},
+				{Path: "/constraints/0/sign", Code: "enum"},
+				{Path: "/constraints/1/terms/0/var", Code: "min_length"},
+			}, ""},
+		{`{"objective": {}, "constraints": 3}`, []Issue{{Path: "/constraints", Code: "type"}}, ""},
+		{`{"objective": {}, "constraints": []}`, []Issue{{Path: "/constraints", Code: "min_items"}}, ""},
+		{`{"objective": `, []Issue{{Code: "invalid_json"}}, ""},
+	}
+	for _, tc := range cases {
+		got := Validate(Request, []byte(tc.src))
+		for i := range got {
+			got[i].Message = ""
+		}
+		assert.Equal(t, Errors(tc.want), got, tc.src)
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	req := models.SimplexRequest{
+		Objective:   models.Objective{N: 2, Coefficients: []float64{3, 2}},
+		Constraints: models.Constraints{Rows: 2, Cols: 3, Vars: []float64{1, 1, 4, 1, 3, 6}, Signs: []string{"<=", ">="}},
+		Integers:    []int{1},
+	}
+	res := simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, req.Objective.Coefficients),
+		Constraints: mat.NewDense(2, 3, req.Constraints.Vars),
+		Signs:       req.Constraints.Signs,
+	})
+	w := httptest.NewRecorder()
+	assert.NoError(t, json.NewEncoder(w).Encode(render.Response(req, res)))
+	assert.Empty(t, Validate(Response, w.Body.Bytes()))
+
+	var body bytes.Buffer
+	assert.NoError(t, json.NewEncoder(&body).Encode(map[string]any{
+		"error":  "x",
+		"errors": Check(models.SimplexRequest{}, Matrix),
+	}))
+	assert.Empty(t, Validate(Error, body.Bytes()))
+}
+
+func TestCheck(t *testing.T) {
+	req := models.SimplexRequest{
+		Objective: models.Objective{N: 3, Coefficients: []float64{1, math.NaN(), 2}, Type: "max", Names: []string{"a ", "s_c", "a "}},
+		Constraints: models.Constraints{
+			Rows: 2, Cols: 4,
+			Vars:  []float64{1, 1, 1, 4, 1, 0, 1, 2},
+			Signs: []string{"range", "range"},
+			Lower: []float64{5},
+			Names: []string{"c", "c"},
+		},
+	}
+	errs := Check(req, Matrix)
+	got := make([][2]string, len(errs))
+	for i, e := range errs {
+		got[i] = [2]string{e.Path, e.Code}
+	}
+	assert.Equal(t, [][2]string{
+		{"/objective/coefficients/1", "not_finite"},
+		{"/objective/type", "enum"},
+		{"/constraints/lower/0", "range_inverted"},
+		{"/constraints/lower/1", "missing_lower"},
+		{"/objective/names/0", "surrounding_space"},
+		{"/objective/names/2", "surrounding_space"},
+		{"/constraints/names/1", "duplicate_name"},
+	}, got)
+
+	// Los mensajes se conservan de la validación anterior
+	assert.Equal(t, "Coeficiente inválido en la posición 1", errs[0].Message)
+}
+
+func TestCheckForms(t *testing.T) {
+	// Rango invertido en la fila 1 y nombre repetido
+	req := models.SimplexRequest{
+		Objective: models.Objective{N: 1, Coefficients: []float64{1}},
+		Constraints: models.Constraints{
+			Rows: 2, Cols: 2,
+			Vars:  []float64{1, 4, 1, 2},
+			Signs: []string{"<=", "range"},
+			Lower: []float64{0, 3},
+			Names: []string{"r", "r"},
+		},
+	}
+	paths := func(errs Errors) []string {
+		out := make([]string, len(errs))
+		for i, e := range errs {
+			out[i] = e.Path
+		}
+		return out
+	}
+	assert.Equal(t, []string{"/constraints/lower/1", "/constraints/names/1"}, paths(Check(req, Matrix)))
+	assert.Equal(t, []string{"/constraints/1/lower", "/constraints/1/name"}, paths(Check(req, RowObjects)))
+	assert.Equal(t, []string{"", ""}, paths(Check(req, Imported)))
+
+	assert.Equal(t, "/constraints/1/rhs", rowPath("/constraints/vars/3", 2))
+	assert.Equal(t, "/constraints/1", rowPath("/constraints/vars/2", 2))
+	assert.Equal(t, "/constraints", rowPath("/constraints/vars", 2))
+	assert.Equal(t, "/constraints", rowPath("/constraints/cols", 2))
+	assert.Equal(t, "/objective/names/0", rowPath("/objective/names/0", 2))
+}
+
+func TestPointer(t *testing.T) {
+	assert.Equal(t, "/a~1b/m~0n/0", pointer("a/b", "m~n", 0))
+}
@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// Validate revisa el documento JSON data contra el esquema name de la versión
+// vigente. Implementa las palabras clave que usan los esquemas publicados: $ref
+// local, type, properties, required, additionalProperties, items, minItems,
+// minimum, minLength, enum y oneOf.
+func Validate(name string, data []byte) Errors {
+	root, ok := compiled[name]
+	if !ok {
+		return Errors{{Code: "unknown_schema", Message: "Esquema desconocido: " + name}}
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return Errors{{Code: "invalid_json", Message: "JSON inválido: " + err.Error()}}
+	}
+	if dec.More() {
+		return Errors{{Code: "invalid_json", Message: "JSON inválido: hay contenido después del documento"}}
+	}
+	v := validator{root: root}
+	v.check(root, doc, "")
+	return v.issues
+}
+
+type validator struct {
+	root   map[string]any
+	issues Errors
+}
+
+func (v *validator) add(path, code, format string, args ...any) {
+	v.issues = append(v.issues, Issue{Path: path, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// resolve sigue un $ref de la forma #/$defs/nombre.
+func (v *validator) resolve(ref string) map[string]any {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		panic("schema: $ref no soportado: " + ref)
+	}
+	defs, _ := v.root["$defs"].(map[string]any)
+	s, ok := defs[name].(map[string]any)
+	if !ok {
+		panic("schema: $ref sin definición: " + ref)
+	}
+	return s
+}
+
+func (v *validator) check(s map[string]any, doc any, path string) {
+	if ref, ok := s["$ref"].(string); ok {
+		v.check(v.resolve(ref), doc, path)
+		return
+	}
+	if alts, ok := s["oneOf"].([]any); ok {
+		v.oneOf(alts, doc, path)
+		return
+	}
+	if t, ok := s["type"]; ok && !matchesType(t, doc) {
+		v.add(path, "type", "%s debe ser %s y es %s", describePath(path), typeNames(t), typeOf(doc))
+		return
+	}
+	if enum, ok := s["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return equal(e, doc) }) {
+		opts := make([]string, len(enum))
+		for i, e := range enum {
+			opts[i] = fmt.Sprint(e)
+		}
+		v.add(path, "enum", "%s tiene un valor inválido %s: use %s", describePath(path), display(doc), strings.Join(opts, ", "))
+		return
+	}
+
+	switch d := doc.(type) {
+	case map[string]any:
+		v.object(s, d, path)
+	case []any:
+		if lo, ok := s["minItems"].(float64); ok && float64(len(d)) < lo {
+			v.add(path, "min_items", "%s debe tener al menos %g elementos", describePath(path), lo)
+		}
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, e := range d {
+				v.check(items, e, pointerJoin(path, i))
+			}
+		}
+	case json.Number:
+		if lo, ok := s["minimum"].(float64); ok {
+			if f, _ := d.Float64(); f < lo {
+				v.add(path, "minimum", "%s debe ser mayor o igual a %g", describePath(path), lo)
+			}
+		}
+	case string:
+		if lo, ok := s["minLength"].(float64); ok && float64(len([]rune(d))) < lo {
+			v.add(path, "min_length", "%s no puede estar vacío", describePath(path))
+		}
+	}
+}
+
+func (v *validator) object(s map[string]any, d map[string]any, path string) {
+	if req, ok := s["required"].([]any); ok {
+		for _, r := range req {
+			if _, ok := d[r.(string)]; !ok {
+				v.add(pointerJoin(path, r), "required", "Falta el campo obligatorio %s", describePath(pointerJoin(path, r)))
+			}
+		}
+	}
+	props, _ := s["properties"].(map[string]any)
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	// Orden estable para que los errores no dependan del recorrido del mapa
+	slices.Sort(keys)
+	for _, k := range keys {
+		if ps, ok := props[k].(map[string]any); ok {
+			v.check(ps, d[k], pointerJoin(path, k))
+			continue
+		}
+		switch extra := s["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				v.add(pointerJoin(path, k), "additional_property", "Campo no permitido: %s", describePath(pointerJoin(path, k)))
+			}
+		case map[string]any:
+			v.check(extra, d[k], pointerJoin(path, k))
+		}
+	}
+}
+
+// oneOf acepta el documento si cumple exactamente una alternativa. Si no cumple
+// ninguna, informa los problemas de la alternativa cuyo tipo coincide, que suele
+// ser la que el cliente quiso usar.
+func (v *validator) oneOf(alts []any, doc any, path string) {
+	var best Errors
+	matched := 0
+	for _, a := range alts {
+		sub := validator{root: v.root}
+		sub.check(a.(map[string]any), doc, path)
+		if len(sub.issues) == 0 {
+			matched++
+			continue
+		}
+		if best == nil && (sub.issues[0].Code != "type" || sub.issues[0].Path != path) {
+			best = sub.issues
+		}
+	}
+	switch {
+	case matched == 1:
+	case matched > 1:
+		v.add(path, "one_of", "%s coincide con más de una forma", describePath(path))
+	case best != nil:
+		v.issues = append(v.issues, best...)
+	default:
+		v.add(path, "type", "%s no tiene una forma válida", describePath(path))
+	}
+}
+
+// matchesType indica si doc es del tipo t, que puede ser un nombre o una lista.
+func matchesType(t any, doc any) bool {
+	if list, ok := t.([]any); ok {
+		return slices.ContainsFunc(list, func(x any) bool { return matchesType(x, doc) })
+	}
+	switch t {
+	case "object":
+		_, ok := doc.(map[string]any)
+		return ok
+	case "array":
+		_, ok := doc.([]any)
+		return ok
+	case "string":
+		_, ok := doc.(string)
+		return ok
+	case "boolean":
+		_, ok := doc.(bool)
+		return ok
+	case "null":
+		return doc == nil
+	case "number":
+		_, ok := doc.(json.Number)
+		return ok
+	case "integer":
+		// Como en JSON Schema, 2.0 cuenta como entero
+		n, ok := doc.(json.Number)
+		if !ok {
+			return false
+		}
+		f, _, err := big.ParseFloat(n.String(), 10, 256, big.ToNearestEven)
+		return err == nil && f.IsInt()
+	}
+	return false
+}
+
+var typeWords = map[string]string{
+	"object":  "un objeto",
+	"array":   "una lista",
+	"string":  "un texto",
+	"boolean": "un booleano",
+	"null":    "null",
+	"number":  "un número",
+	"integer": "un número entero",
+}
+
+func typeNames(t any) string {
+	if list, ok := t.([]any); ok {
+		words := make([]string, len(list))
+		for i, x := range list {
+			words[i] = typeWords[x.(string)]
+		}
+		return strings.Join(words, " o ")
+	}
+	return typeWords[t.(string)]
+}
+
+func typeOf(doc any) string {
+	switch d := doc.(type) {
+	case map[string]any:
+		return typeWords["object"]
+	case []any:
+		return typeWords["array"]
+	case string:
+		return typeWords["string"]
+	case bool:
+		return typeWords["boolean"]
+	case json.Number:
+		if matchesType("integer", d) {
+			return typeWords["integer"]
+		}
+		return typeWords["number"]
+	}
+	return "null"
+}
+
+// equal compara un valor del esquema con uno del documento.
+func equal(schemaValue, doc any) bool {
+	if n, ok := doc.(json.Number); ok {
+		f, err := n.Float64()
+		return err == nil && schemaValue == f
+	}
+	return schemaValue == doc
+}
+
+func display(doc any) string {
+	if s, ok := doc.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprint(doc)
+}
+
+// describePath nombra el valor de un puntero en los mensajes.
+func describePath(path string) string {
+	if path == "" {
+		return "La solicitud"
+	}
+	return "El campo " + path
+}
+
+func pointerJoin(path string, part any) string {
+	return path + pointer(part)
+}
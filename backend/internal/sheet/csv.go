@@ -0,0 +1,52 @@
+package sheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"io"
+
+	"autosimplex/internal/models"
+)
+
+// ReadCSV lee un modelo desde un CSV. El separador puede ser "," o ";"; con ";"
+// (el formato que exportan las planillas en español) los decimales pueden
+// escribirse con coma.
+func ReadCSV(r io.Reader) (models.SimplexRequest, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	// El separador se decide por la primera línea
+	first, _, _ := bufio.NewReader(bytes.NewReader(data)).ReadLine()
+	semicolon := bytes.Count(first, []byte(";")) > bytes.Count(first, []byte(","))
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	if semicolon {
+		cr.Comma = ';'
+	}
+	// encoding/csv salta las líneas vacías: guardar la línea real de cada registro
+	var grid [][]string
+	var lines []int
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return models.SimplexRequest{}, err
+		}
+		line, _ := cr.FieldPos(0)
+		grid = append(grid, rec)
+		lines = append(lines, line)
+	}
+	m, err := model(grid, lines, semicolon)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
@@ -0,0 +1,186 @@
+// Package sheet importa modelos escritos como planilla: una fila de encabezado con
+// los nombres de las variables, una fila con el objetivo y una fila por
+// restricción con su signo y su lado derecho. Acepta CSV y XLSX.
+//
+//	        , mesas, sillas, signo, rhs
+//	max     , 3    , 2
+//	madera  , 1    , 1     , <=   , 4
+//	horas   , 1    , 3     , <=   , 6
+//
+// La primera columna nombra cada fila; la del objetivo dice max o min. Las
+// columnas "signo" (o "sign"), "rhs" y, para filas de rango, "lower" se reconocen
+// por su encabezado y el resto son variables. Una celda vacía vale 0.
+package sheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/parser"
+)
+
+// model interpreta la grilla de celdas (ya leída del CSV o del XLSX). lines da el
+// número de línea de cada fila para los errores; si es nil se usa la posición.
+func model(grid [][]string, lines []int, decimalComma bool) (models.LinearModel, error) {
+	var m models.LinearModel
+	// Saltar filas vacías al comienzo
+	start := 0
+	for start < len(grid) && blank(grid[start]) {
+		start++
+	}
+	if start == len(grid) {
+		return m, fmt.Errorf("la planilla está vacía")
+	}
+	errAt := func(row, col int, format string, args ...any) error {
+		line := row + 1
+		if row < len(lines) {
+			line = lines[row]
+		}
+		return &parser.Error{Line: line, Column: col + 1, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	header := grid[start]
+	signCol, rhsCol, lowerCol := -1, -1, -1
+	var varCols []int
+	seen := map[string]bool{}
+	for j := 1; j < len(header); j++ {
+		name := strings.TrimSpace(header[j])
+		switch strings.ToLower(name) {
+		case "signo", "sign":
+			signCol = j
+		case "rhs", "ld", "lado derecho":
+			rhsCol = j
+		case "lower", "inferior":
+			lowerCol = j
+		case "":
+			if !blankColumn(grid[start+1:], j) {
+				return m, errAt(start, j, "falta el nombre de la variable")
+			}
+		default:
+			if seen[name] {
+				return m, errAt(start, j, "variable %q repetida", name)
+			}
+			seen[name] = true
+			varCols = append(varCols, j)
+			m.Variables = append(m.Variables, name)
+		}
+	}
+	if len(varCols) == 0 {
+		return m, errAt(start, 1, "el encabezado no tiene variables")
+	}
+	if signCol < 0 || rhsCol < 0 {
+		return m, errAt(start, 0, "el encabezado debe tener las columnas signo y rhs")
+	}
+
+	cell := func(row []string, j int) string {
+		if j < len(row) {
+			return strings.TrimSpace(row[j])
+		}
+		return ""
+	}
+	number := func(i int, row []string, j int) (float64, error) {
+		s := cell(row, j)
+		if s == "" {
+			return 0, nil
+		}
+		if decimalComma {
+			s = strings.ReplaceAll(s, ",", ".")
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, errAt(i, j, "número inválido %q", cell(row, j))
+		}
+		return v, nil
+	}
+
+	haveObjective := false
+	for i := start + 1; i < len(grid); i++ {
+		row := grid[i]
+		if blank(row) {
+			continue
+		}
+		label := cell(row, 0)
+		var terms []models.Term
+		for k, j := range varCols {
+			v, err := number(i, row, j)
+			if err != nil {
+				return m, err
+			}
+			if v != 0 {
+				terms = append(terms, models.Term{Var: m.Variables[k], Coef: v})
+			}
+		}
+
+		if !haveObjective {
+			switch strings.ToLower(label) {
+			case "max", "maximize", "maximizar":
+				m.Sense = "maximize"
+			case "min", "minimize", "minimizar":
+				m.Sense = "minimize"
+			default:
+				return m, errAt(i, 0, "la primera fila debe ser el objetivo: max o min, no %q", label)
+			}
+			m.Objective = terms
+			haveObjective = true
+			continue
+		}
+
+		sign, ok := normalizeSign(cell(row, signCol))
+		if !ok {
+			return m, errAt(i, signCol, "signo inválido %q: use <=, >=, = o range", cell(row, signCol))
+		}
+		rhs, err := number(i, row, rhsCol)
+		if err != nil {
+			return m, err
+		}
+		r := models.Row{Name: label, Terms: terms, Sign: sign, RHS: rhs}
+		if sign == "range" {
+			if lowerCol < 0 || cell(row, lowerCol) == "" {
+				return m, errAt(i, signCol, "la fila de rango necesita un valor en la columna lower")
+			}
+			if r.Lower, err = number(i, row, lowerCol); err != nil {
+				return m, err
+			}
+		}
+		m.Rows = append(m.Rows, r)
+	}
+	if !haveObjective {
+		return m, errAt(start, 0, "falta la fila del objetivo")
+	}
+	return m, nil
+}
+
+// normalizeSign acepta las variantes habituales de los signos en una planilla.
+func normalizeSign(s string) (string, bool) {
+	switch strings.ToLower(s) {
+	case "<=", "=<", "<", "≤", "":
+		return "<=", true
+	case ">=", "=>", ">", "≥":
+		return ">=", true
+	case "=", "==":
+		return "=", true
+	case "range", "rango":
+		return "range", true
+	}
+	return "", false
+}
+
+func blank(row []string) bool {
+	for _, c := range row {
+		if strings.TrimSpace(c) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func blankColumn(rows [][]string, j int) bool {
+	for _, row := range rows {
+		if j < len(row) && strings.TrimSpace(row[j]) != "" {
+			return false
+		}
+	}
+	return true
+}
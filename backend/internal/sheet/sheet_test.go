@@ -0,0 +1,152 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/parser"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCSV(t *testing.T) {
+	src := ",mesas,sillas,signo,rhs,lower\n" +
+		"max,3,2,,,\n" +
+		"madera,1,1,<=,4,\n" +
+		"\n" +
+		"horas,1,3,≤,6,\n" +
+		"pedido,,1,range,3,1\n"
+	req, err := ReadCSV(strings.NewReader(src))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "maximize", req.Objective.Type)
+	assert.Equal(t, []string{"mesas", "sillas"}, req.Objective.Names)
+	assert.Equal(t, []float64{3, 2}, req.Objective.Coefficients)
+	assert.Equal(t, []string{"madera", "horas", "pedido"}, req.Constraints.Names)
+	assert.Equal(t, []string{"<=", "<=", "range"}, req.Constraints.Signs)
+	assert.Equal(t, []float64{
+		1, 1, 4,
+		1, 3, 6,
+		0, 1, 3,
+	}, req.Constraints.Vars)
+	assert.Equal(t, 1.0, req.Constraints.Lower[2])
+}
+
+func TestReadCSVSemicolon(t *testing.T) {
+	// Separador ";" con coma decimal, como exportan las planillas en español
+	src := ";x;y;signo;rhs\nmin;1,5;2;;\nc1;1;1;>=;2,5\n"
+	req, err := ReadCSV(strings.NewReader(src))
+	assert.NoError(t, err)
+	assert.Equal(t, "minimize", req.Objective.Type)
+	assert.Equal(t, []float64{1.5, 2}, req.Objective.Coefficients)
+	assert.Equal(t, []float64{1, 1, 2.5}, req.Constraints.Vars)
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	_, err := ReadCSV(strings.NewReader(",x,y,signo,rhs\nmax,1,2\n\nc1,1,abc,<=,4\n"))
+	var perr *parser.Error
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, 4, perr.Line)
+		assert.Equal(t, 3, perr.Column)
+	}
+
+	_, err = ReadCSV(strings.NewReader(",x,y,rhs\nmax,1,2\nc1,1,1,4\n"))
+	assert.ErrorContains(t, err, "signo y rhs")
+
+	_, err = ReadCSV(strings.NewReader(",x,signo,rhs\nc1,1,<=,4\n"))
+	assert.ErrorContains(t, err, "objetivo")
+}
+
+// book arma un libro mínimo con textos compartidos y la hoja sheet.
+func book(sheet string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	add := func(name, content string) {
+		w, _ := zw.Create(name)
+		_, _ = w.Write([]byte(content))
+	}
+	add("xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Modelo" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+	add("xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="worksheet" Target="worksheets/hoja.xml"/></Relationships>`)
+	add("xl/sharedStrings.xml", `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>mesas</t></si><si><r><t>si</t></r><r><t>llas</t></r></si><si><t>signo</t></si><si><t>rhs</t></si><si><t>max</t></si><si><t>&lt;=</t></si></sst>`)
+	add("xl/worksheets/hoja.xml", sheet)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+func TestReadXLSX(t *testing.T) {
+	// Libro mínimo: textos compartidos, una celda en línea y una fila sin celdas vacías
+	req, err := ReadXLSX(book(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="B1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c><c r="D1" t="s"><v>2</v></c><c r="E1" t="s"><v>3</v></c></row>
+<row r="2"><c r="A2" t="s"><v>4</v></c><c r="B2"><v>3</v></c><c r="C2"><v>2</v></c></row>
+<row r="4"><c r="A4" t="inlineStr"><is><t>madera</t></is></c><c r="B4"><v>1</v></c><c r="C4"><v>1</v></c><c r="D4" t="s"><v>5</v></c><c r="E4"><v>4</v></c></row>
+<row r="5"><c r="B5"><v>1</v></c><c r="C5"><v>3</v></c><c r="D5" t="s"><v>5</v></c><c r="E5"><v>6</v></c></row>
+</sheetData></worksheet>`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mesas", "sillas"}, req.Objective.Names)
+	assert.Equal(t, []float64{3, 2}, req.Objective.Coefficients)
+	assert.Equal(t, []string{"madera", ""}, req.Constraints.Names)
+	assert.Equal(t, []float64{1, 1, 4, 1, 3, 6}, req.Constraints.Vars)
+
+	_, err = ReadXLSX([]byte("no es un zip"))
+	assert.Error(t, err)
+}
+
+func TestReadXLSXWithoutRefs(t *testing.T) {
+	// Sin r en filas ni celdas: cada una va a continuación de la anterior; una
+	// fila con r salta a su posición
+	req, err := ReadXLSX(book(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row><c/><c t="s"><v>0</v></c><c t="s"><v>1</v></c><c t="s"><v>2</v></c><c t="s"><v>3</v></c></row>
+<row><c t="s"><v>4</v></c><c><v>3</v></c><c><v>2</v></c></row>
+<row r="4"><c t="inlineStr"><is><t>madera</t></is></c><c><v>1</v></c><c><v>1</v></c><c t="s"><v>5</v></c><c><v>4</v></c></row>
+<row><c/><c><v>1</v></c><c><v>3</v></c><c t="s"><v>5</v></c><c><v>6</v></c></row>
+</sheetData></worksheet>`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"mesas", "sillas"}, req.Objective.Names)
+	assert.Equal(t, []float64{3, 2}, req.Objective.Coefficients)
+	assert.Equal(t, []float64{1, 1, 4, 1, 3, 6}, req.Constraints.Vars)
+
+	// Un índice de texto compartido inválido se informa con la celda calculada
+	_, err = ReadXLSX(book(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row><c/><c t="s"><v>99</v></c></row></sheetData></worksheet>`))
+	assert.ErrorContains(t, err, "B1")
+}
+
+func TestReadXLSXFarReferences(t *testing.T) {
+	// Una fila lejana dentro de los límites no reserva las filas vacías de por
+	// medio y sus errores conservan el número de fila
+	const head = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="B1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c><c r="D1" t="s"><v>2</v></c><c r="E1" t="s"><v>3</v></c></row>
+<row r="2"><c r="A2" t="s"><v>4</v></c><c r="B2"><v>3</v></c><c r="C2"><v>2</v></c></row>`
+	req, err := ReadXLSX(book(head + `<row r="1000000"><c r="A1000000" t="inlineStr"><is><t>madera</t></is></c><c r="B1000000"><v>1</v></c><c r="D1000000" t="s"><v>5</v></c><c r="E1000000"><v>4</v></c></row></sheetData></worksheet>`))
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1, 0, 4}, req.Constraints.Vars)
+
+	_, err = ReadXLSX(book(head + `<row r="900000"><c r="A900000" t="inlineStr"><is><t>madera</t></is></c><c r="B900000" t="inlineStr"><is><t>uno</t></is></c></row></sheetData></worksheet>`))
+	var perr *parser.Error
+	if assert.True(t, errors.As(err, &perr)) {
+		assert.Equal(t, 900000, perr.Line)
+	}
+
+	// Referencias fuera de los límites de un XLSX
+	for _, sheet := range []string{
+		`<row r="5000000"><c><v>1</v></c></row>`,
+		`<row><c r="A5000000"><v>1</v></c></row>`,
+		`<row><c r="XFE1"><v>1</v></c></row>`,
+		`<row><c r="A99999999999999999999"><v>1</v></c></row>`,
+	} {
+		_, err := ReadXLSX(book(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + sheet + `</sheetData></worksheet>`))
+		assert.Error(t, err, sheet)
+	}
+
+	// Muchas filas con una celda en la última columna: la grilla tendría más de
+	// maxCells celdas
+	var wide strings.Builder
+	for i := range maxCells/maxCols + 1 {
+		fmt.Fprintf(&wide, `<row><c r="XFD%d"><v>1</v></c></row>`, i+1)
+	}
+	_, err = ReadXLSX(book(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` + wide.String() + `</sheetData></worksheet>`))
+	assert.ErrorContains(t, err, "demasiado grande")
+}
@@ -0,0 +1,242 @@
+package sheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"autosimplex/internal/models"
+)
+
+// Límites de una hoja: los de Excel para filas y columnas y, para la grilla que
+// se arma en memoria, una cantidad máxima de celdas.
+const (
+	maxRows  = 1 << 20 // 1.048.576
+	maxCols  = 1 << 14 // 16.384, la columna XFD
+	maxCells = 1 << 20
+)
+
+// ReadXLSX lee un modelo desde la primera hoja de un libro XLSX con el mismo
+// diseño que el CSV. Solo se leen los valores de las celdas, no las fórmulas.
+func ReadXLSX(data []byte) (models.SimplexRequest, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return models.SimplexRequest{}, fmt.Errorf("el archivo no es un XLSX válido: %w", err)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var shared []string
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Items []richText `xml:"si"`
+		}
+		if err := decodeXML(f, &sst); err != nil {
+			return models.SimplexRequest{}, err
+		}
+		for _, si := range sst.Items {
+			shared = append(shared, si.String())
+		}
+	}
+
+	sheetFile, err := firstSheet(files)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	var ws struct {
+		Rows []struct {
+			Ref   int `xml:"r,attr"`
+			Cells []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline richText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	if err := decodeXML(sheetFile, &ws); err != nil {
+		return models.SimplexRequest{}, err
+	}
+
+	// Las filas y celdas vacías no aparecen en el XML: ubicarlas por su referencia
+	// (B3). Algunos programas omiten las referencias, que son optativas: entonces
+	// cada fila o celda va a continuación de la anterior. Las celdas se juntan por
+	// fila y la grilla solo tiene las filas con valores, con su número original
+	// para los errores: una referencia lejana (A1000000) no reserva memoria para
+	// las filas vacías de por medio.
+	rows := map[int][]string{}
+	cells := 0
+	r := -1
+	for _, row := range ws.Rows {
+		r++
+		if row.Ref > 0 {
+			r = row.Ref - 1
+		}
+		if r >= maxRows {
+			return models.SimplexRequest{}, fmt.Errorf("la fila %d supera el máximo de %d de un XLSX", r+1, maxRows)
+		}
+		col := -1
+		for _, c := range row.Cells {
+			col++
+			if c.Ref != "" {
+				var ok bool
+				if col, r, ok = cellRef(c.Ref); !ok {
+					return models.SimplexRequest{}, fmt.Errorf("referencia de celda inválida %q", c.Ref)
+				}
+			}
+			if col >= maxCols {
+				return models.SimplexRequest{}, fmt.Errorf("la celda %s supera el máximo de %d columnas de un XLSX", cellName(col, r), maxCols)
+			}
+			value := c.Value
+			switch c.Type {
+			case "s":
+				var idx int
+				if _, err := fmt.Sscan(c.Value, &idx); err != nil || idx < 0 || idx >= len(shared) {
+					return models.SimplexRequest{}, fmt.Errorf("texto compartido inválido en la celda %s", cellName(col, r))
+				}
+				value = shared[idx]
+			case "inlineStr":
+				value = c.Inline.String()
+			}
+			// Las celdas vacías a la izquierda también ocupan la grilla
+			if grow := col + 1 - len(rows[r]); grow > 0 {
+				if cells += grow; cells > maxCells {
+					return models.SimplexRequest{}, fmt.Errorf("la hoja es demasiado grande: más de %d celdas", maxCells)
+				}
+				rows[r] = append(rows[r], make([]string, grow)...)
+			}
+			rows[r][col] = value
+		}
+	}
+	numbers := make([]int, 0, len(rows))
+	for r := range rows {
+		numbers = append(numbers, r)
+	}
+	slices.Sort(numbers)
+	grid := make([][]string, len(numbers))
+	lines := make([]int, len(numbers))
+	for i, r := range numbers {
+		grid[i], lines[i] = rows[r], r+1
+	}
+	m, err := model(grid, lines, false)
+	if err != nil {
+		return models.SimplexRequest{}, err
+	}
+	return m.ToRequest()
+}
+
+// richText es un texto de Excel: simple (<t>) o con formato (<r><t>).
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	var b strings.Builder
+	b.WriteString(t.Text)
+	for _, r := range t.Runs {
+		b.WriteString(r.Text)
+	}
+	return b.String()
+}
+
+// firstSheet busca la primera hoja del libro siguiendo workbook.xml y sus relaciones.
+func firstSheet(files map[string]*zip.File) (*zip.File, error) {
+	var wb struct {
+		Sheets []struct {
+			ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Items []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	wbFile, okWb := files["xl/workbook.xml"]
+	relFile, okRel := files["xl/_rels/workbook.xml.rels"]
+	if okWb && okRel {
+		if err := decodeXML(wbFile, &wb); err != nil {
+			return nil, err
+		}
+		if err := decodeXML(relFile, &rels); err != nil {
+			return nil, err
+		}
+		if len(wb.Sheets) > 0 {
+			for _, r := range rels.Items {
+				if r.ID != wb.Sheets[0].ID {
+					continue
+				}
+				target := strings.TrimPrefix(r.Target, "/")
+				if !strings.HasPrefix(target, "xl/") {
+					target = path.Join("xl", target)
+				}
+				if f, ok := files[target]; ok {
+					return f, nil
+				}
+			}
+		}
+	}
+	if f, ok := files["xl/worksheets/sheet1.xml"]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("el libro no tiene hojas")
+}
+
+func decodeXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, 64<<20)).Decode(v); err != nil {
+		return fmt.Errorf("%s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// cellRef convierte una referencia como "B3" en columna y fila base 0. Una
+// referencia fuera de los límites de un XLSX (maxRows, maxCols) no es válida.
+func cellRef(ref string) (col, row int, ok bool) {
+	i := 0
+	for i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z' {
+		col = col*26 + int(ref[i]-'A'+1)
+		if col > maxCols {
+			return 0, 0, false
+		}
+		i++
+	}
+	if i == 0 || i == len(ref) {
+		return 0, 0, false
+	}
+	for _, r := range ref[i:] {
+		if r < '0' || r > '9' {
+			return 0, 0, false
+		}
+		if row = row*10 + int(r-'0'); row > maxRows {
+			return 0, 0, false
+		}
+	}
+	if row == 0 {
+		return 0, 0, false
+	}
+	return col - 1, row - 1, true
+}
+
+// cellName es la inversa de cellRef: columna y fila base 0 a "B3".
+func cellName(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return fmt.Sprintf("%s%d", name, row+1)
+}
@@ -0,0 +1,94 @@
+package simplex
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// MValue representa un valor de la forma Const + M·Coef, como se escribe el
+// método de la gran M en el pizarrón ("3 − 2M").
+type MValue struct {
+	Const float64 `json:"const"`
+	M     float64 `json:"m"`
+}
+
+// String formatea el valor simbólicamente: "0", "5", "M", "−2M", "3 − 2M".
+func (v MValue) String() string {
+	c, k := roundM(v.Const), roundM(v.M)
+	if k == 0 {
+		return FormatNumber(c)
+	}
+	mTerm := "M"
+	if abs := math.Abs(k); abs != 1 {
+		mTerm = FormatNumber(abs) + "M"
+	}
+	if c == 0 {
+		if k < 0 {
+			return "−" + mTerm
+		}
+		return mTerm
+	}
+	if k < 0 {
+		return FormatNumber(c) + " − " + mTerm
+	}
+	return FormatNumber(c) + " + " + mTerm
+}
+
+// FormatValues formatea una fila del tableau para los informes: en forma
+// simbólica si sym tiene un valor por cada elemento de v (gran M) y con
+// FormatNumber si no.
+func FormatValues(v []float64, sym []MValue) []string {
+	if len(sym) == len(v) && len(sym) > 0 {
+		out := make([]string, len(sym))
+		for i, s := range sym {
+			out[i] = s.String()
+		}
+		return out
+	}
+	out := make([]string, len(v))
+	for i, x := range v {
+		out[i] = FormatNumber(x)
+	}
+	return out
+}
+
+// MarshalJSON agrega la representación simbólica junto a las dos componentes.
+func (v MValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Const float64 `json:"const"`
+		M     float64 `json:"m"`
+		Text  string  `json:"text"`
+	}{v.Const, v.M, v.String()})
+}
+
+// mValues combina las componentes constante y M en un slice de MValue.
+func mValues(consts, ms []float64) []MValue {
+	out := make([]MValue, len(consts))
+	for i := range consts {
+		out[i] = MValue{Const: consts[i], M: ms[i]}
+	}
+	return out
+}
+
+// roundM descarta el ruido numérico del álgebra matricial.
+func roundM(v float64) float64 {
+	r := math.Round(v*1e9) / 1e9
+	if r == 0 {
+		return 0
+	}
+	return r
+}
+
+// FormatNumber imprime v con hasta 4 decimales y usa el signo menos
+// tipográfico, como MValue.String.
+func FormatNumber(v float64) string {
+	s := strconv.FormatFloat(math.Round(v*1e4)/1e4, 'f', -1, 64)
+	if s == "-0" {
+		return "0"
+	}
+	if len(s) > 0 && s[0] == '-' {
+		return "−" + s[1:]
+	}
+	return s
+}
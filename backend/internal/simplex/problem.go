@@ -0,0 +1,81 @@
+package simplex
+
+import (
+	"strings"
+
+	"autosimplex/internal/models"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Problem agrupa los datos de entrada del solver.
+type Problem struct {
+	// Objective contiene los coeficientes de la función objetivo.
+	Objective mat.Vector
+	// Minimize indica que el objetivo se minimiza; por defecto se maximiza.
+	Minimize bool
+	// Constraints tiene una fila [a1 ... an b] por restricción.
+	Constraints *mat.Dense
+	// Signs contiene "<=", ">=", "=" o "range" por restricción; si falta se asume "<=".
+	Signs []string
+	// Lower contiene la cota inferior lo de cada fila "range" (lo <= a·x <= b);
+	// se ignora en las demás filas.
+	Lower []float64
+	// VarNames y RowNames nombran opcionalmente las variables de decisión y las
+	// restricciones; una entrada vacía conserva el nombre por defecto (x1, s1, ...).
+	VarNames []string
+	RowNames []string
+}
+
+// FromRequest arma el problema del solver a partir de una solicitud ya validada.
+func FromRequest(req models.SimplexRequest) Problem {
+	// Construir vector objetivo. El sentido (max/min) lo resuelve el solver,
+	// así las tablas muestran los coeficientes tal como fueron ingresados.
+	objective := mat.NewVecDense(req.Objective.N, req.Objective.Coefficients)
+	isMinimize := strings.ToLower(strings.TrimSpace(req.Objective.Type)) == "minimize"
+
+	// Matriz de restricciones (incluye lado derecho)
+	rows := req.Constraints.Rows
+	constraintMatrix := mat.NewDense(rows, req.Constraints.Cols, req.Constraints.Vars)
+
+	// Construir slice de signos: usar signos proporcionados si existen, sino usar "<=" por defecto
+	signs := req.Constraints.Signs
+	if len(signs) == 0 {
+		signs = make([]string, rows)
+		for i := range rows {
+			signs[i] = "<="
+		}
+	}
+
+	return Problem{
+		Objective:   objective,
+		Minimize:    isMinimize,
+		Constraints: constraintMatrix,
+		Signs:       signs,
+		Lower:       req.Constraints.Lower,
+		VarNames:    req.Objective.Names,
+		RowNames:    req.Constraints.Names,
+	}
+}
+
+// Result es la salida completa de SolveProblem.
+type Result struct {
+	OptimalValue float64       `json:"optimal_value"`
+	Solution     []float64     `json:"solution"`
+	Steps        []SimplexStep `json:"steps"`
+	Warning      string        `json:"warning"`
+	// Variables describe cada columna de la matriz extendida.
+	Variables []Variable `json:"variables"`
+	Status    Status     `json:"status"`
+}
+
+// Status es el veredicto con el que terminó el algoritmo.
+type Status string
+
+const (
+	StatusOptimal        Status = "optimal"
+	StatusInfeasible     Status = "infeasible"
+	StatusUnbounded      Status = "unbounded"
+	StatusSingular       Status = "singular"
+	StatusIterationLimit Status = "iteration_limit"
+)
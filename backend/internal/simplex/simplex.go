@@ -21,99 +21,58 @@ func Solve(maximize mat.Vector, constraints *mat.Dense) (float64, []float64, []S
 // matriz de restricciones (las filas son [a1 ... an b]). 'signs' contiene uno de
 // "<=", ">=", o "=" por restricción. Usa una estrategia Big-M para variables artificiales.
 func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string) (float64, []float64, []SimplexStep, string) {
+	res := SolveProblem(Problem{Objective: maximize, Constraints: constraints, Signs: signs})
+	return res.OptimalValue, res.Solution, res.Steps, res.Warning
+}
+
+// SolveProblem resuelve p y devuelve el resultado completo, incluido el catálogo
+// de variables de la matriz extendida. Si p.Minimize es verdadero el problema se
+// resuelve como minimización: las artificiales se penalizan con +M y entra la
+// variable con el cj - zj más negativo, de modo que las tablas muestran los
+// coeficientes originales.
+func SolveProblem(p Problem) Result {
 	const M = 1e7
 	var steps []SimplexStep
 
+	objective, constraints := p.Objective, p.Constraints
 	m, cols := constraints.Dims()
-	n := maximize.Len()
+	n := objective.Len()
+	// sense vale 1 al maximizar y -1 al minimizar; multiplica a cj - zj para
+	// que el criterio de optimalidad sea siempre "ningún valor positivo".
+	sense := 1.0
+	if p.Minimize {
+		sense = -1
+	}
 	if cols != n+1 {
 		warning := "Cantidad de columnas no coinciden con variables"
-		return 0, nil, steps, warning
+		return Result{Steps: steps, Warning: warning}
 	}
 
-	// Contar variables extra y construir matriz A extendida
-	// Agregaremos holgura (para <=), exceso+artificial (para >=), y artificial (para =)
-	extraCols := 0
-	for i := range m {
-		s := "<="
-		if i < len(signs) {
-			s = signs[i]
-		}
-		switch s {
-		case "<=":
-			extraCols += 1 // holgura
-		case ">=":
-			extraCols += 2 // exceso + artificial
-		case "=":
-			extraCols += 1 // artificial
-		default:
-			extraCols += 1
+	// Forma estándar: matriz extendida, lado derecho, costos con la penalidad M y
+	// base inicial (ver buildForm)
+	f := buildForm(p, M)
+	vars, A, b, c, cM, baseVars, artIndices := f.vars, f.A, f.b, f.c, f.cM, f.baseVars, f.artIndices
+	totalVars := len(vars)
+
+	// Cotas superiores de las variables (solo holguras y excesos de rangos). Se usa
+	// la técnica de cota superior: cuando una variable acotada llega a su cota se
+	// reemplaza por su complemento x' = u - x, cuya columna es la opuesta, de modo
+	// que las no básicas siempre valen 0 y el tableau conserva su forma habitual.
+	upper := make([]float64, totalVars)
+	var flipped []bool
+	for j, v := range vars {
+		upper[j] = math.Inf(1)
+		if v.Upper != nil {
+			upper[j] = *v.Upper
 		}
 	}
-
-	totalVars := n + extraCols
-
-	// Construir A_extendida
-	A := mat.NewDense(m, totalVars, nil)
-	// Llenar variables originales
-	for i := range m {
-		for j := range n {
-			A.Set(i, j, constraints.At(i, j))
-		}
-	}
-
-	// Rastrear índices y variable base por fila
-	col := n
-	baseVars := make([]int, m) // índices base 1 de variables básicas por fila
-	artIndices := []int{}
-	for i := range m {
-		s := "<="
-		if i < len(signs) {
-			s = signs[i]
-		}
-		switch s {
-		case "<=":
-			A.Set(i, col, 1)
-			baseVars[i] = col + 1
-			col++
-		case ">=":
-			// exceso
-			A.Set(i, col, -1)
-			col++
-			// artificial
-			A.Set(i, col, 1)
-			baseVars[i] = col + 1
-			artIndices = append(artIndices, col)
-			col++
-		case "=":
-			// artificial
-			A.Set(i, col, 1)
-			baseVars[i] = col + 1
-			artIndices = append(artIndices, col)
-			col++
-		default:
-			A.Set(i, col, 1)
-			baseVars[i] = col + 1
-			col++
+	for _, v := range vars {
+		if v.Upper != nil {
+			flipped = make([]bool, totalVars)
+			break
 		}
 	}
 
-	// Construir objetivo c (1 x totalVars). Variables artificiales obtienen penalidad -M
-	c := mat.NewDense(1, totalVars, make([]float64, totalVars))
-	for j := range n {
-		c.Set(0, j, maximize.At(j, 0))
-	}
-	for _, ai := range artIndices {
-		c.Set(0, ai, -M)
-	}
-
-	// Construir vector b
-	bData := make([]float64, m)
-	for i := range m {
-		bData[i] = constraints.At(i, n)
-	}
-	b := mat.NewVecDense(m, bData)
-
 	// Ahora proceder con iteraciones simplex similar a implementación anterior,
 	// pero usando nuestras A, c, b y baseVars construidas.
 	ATrans := mat.DenseCopyOf(A.T())
@@ -142,7 +101,7 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 		if err := lu.SolveVecTo(yCol, true, cBVec); err != nil {
 			// base singular -> infactible
 			warning := "Matriz singular, problema infactible o mal planteado"
-			return 0, nil, steps, warning
+			return Result{Steps: steps, Warning: warning, Variables: vars, Status: StatusSingular}
 		}
 		// y como fila
 		y := mat.NewDense(1, m, nil)
@@ -168,12 +127,12 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 		yAN := mat.NewDense(1, len(nonBase), nil)
 		yAN.Mul(y, AN)
 
-		// Elegir variable entrante: cualquier índice donde cN > yAN
+		// Elegir variable entrante: cualquier índice donde cN > yAN al maximizar
+		// (cN < yAN al minimizar), tomando la de mayor mejora
 		entering := -1
 		enteringVal := 0.0
 		for i := range nonBase {
-			if cN.At(0, i) > yAN.At(0, i)+1e-9 {
-				val := cN.At(0, i) - yAN.At(0, i)
+			if val := sense * (cN.At(0, i) - yAN.At(0, i)); val > 1e-9 {
 				if entering == -1 || val > enteringVal || nonBase[i] < nonBase[entering] {
 					entering = i
 					enteringVal = val
@@ -181,24 +140,18 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 			}
 		}
 
+		// Registrar el tableau de esta iteración, haya o no pivoteo
+		step := tableauStep(iter, vars, flipped, ATrans, c, cM, &lu, b, baseVars, nonBase)
+		step.ReducedCosts = matDenseToSlice(yAN)
+
 		if entering == -1 {
 			// Verificar si hay variables artificiales en la base (problema infactible)
-			for i := range m {
-				bv := baseVars[i] - 1
-				// Si la variable básica es artificial y tiene valor positivo
-				if contains(artIndices, bv) && b.At(i, 0) > 1e-9 {
-					warning := "Problema infactible: no existe solución"
-					// Devolver solución parcial alcanzada hasta el momento
-					solution := make([]float64, n)
-					for j := range m {
-						bvj := baseVars[j] - 1
-						if bvj < n {
-							val := b.At(j, 0)
-							solution[bvj] = val
-						}
-					}
-					return 0, solution, steps, warning
-				}
+			if positiveArtificial(baseVars, artIndices, b) {
+				warning := "Problema infactible: no existe solución"
+				step.Status = StatusInfeasible
+				steps = append(steps, step)
+				// Devolver solución parcial alcanzada hasta el momento
+				return Result{Solution: basicSolution(n, baseVars, b), Steps: steps, Warning: warning, Variables: vars, Status: StatusInfeasible}
 			}
 
 			// Verificar si hay infinitas soluciones (costo reducido = 0 para variables no básicas)
@@ -229,10 +182,15 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 			if hasInfiniteSolutions {
 				warning = "Solución óptima no única: existen infinitas soluciones"
 			}
-			return optimal, solution, steps, warning
+			step.Status = StatusOptimal
+			steps = append(steps, step)
+			return Result{OptimalValue: optimal, Solution: solution, Steps: steps, Warning: warning, Variables: vars, Status: StatusOptimal}
 		}
 
 		enteringVar := nonBase[entering]
+		step.EnteringVar = enteringVar
+		step.EnteringLabel = label(vars, flipped, enteringVar)
+		step.PivotCol = enteringVar - 1
 
 		// Obtener columna a para enteringVar
 		raw := ATrans.RawRowView(enteringVar - 1)
@@ -245,7 +203,7 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 		dVec := mat.NewVecDense(m, nil)
 		if err := lu.SolveVecTo(dVec, false, aVec); err != nil {
 			warning := "Solución no única, problema infactible o degenerado"
-			return 0, nil, steps, warning
+			return Result{Steps: steps, Warning: warning, Variables: vars, Status: StatusSingular}
 		}
 
 		// Prueba de razón b_i / d_i para d_i > 0
@@ -261,74 +219,64 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 				}
 			}
 		}
-		if leavingIndex == -1 {
-			warning := "Problema no acotado"
-			return 0, nil, steps, warning
-		}
-
-		// Preparar paso
-		currentBaseVars := append([]int{}, baseVars...)
-		currentNonBaseVars := append([]int{}, nonBase...)
-		reducedCosts := matDenseToSlice(yAN)
-		bVector := matVecToSlice(b)
-		newBaseVar := enteringVar
-		leavingVar := baseVars[leavingIndex]
-		lowestValueOfT := minRatio
-
-		// Construir tableau completo: para cada columna de variable j, calcular B^{-1} * A[:, j]
-		// las filas del tableau contendrán coeficientes para cada variable y el RHS final
-		tableRows := make([][]float64, m)
-		for i := 0; i < m; i++ {
-			tableRows[i] = make([]float64, totalVars+1) // +1 para R
-		}
-		// encabezado cj
-		cj := make([]float64, totalVars)
-		for j := 0; j < totalVars; j++ {
-			cj[j] = c.At(0, j)
-			// resolver B^{-1} * A[:, j]
-			rawCol := ATrans.RawRowView(j)
-			colVec := mat.NewVecDense(m, nil)
-			aVec := mat.NewVecDense(m, nil)
-			for i := 0; i < m; i++ {
-				aVec.SetVec(i, rawCol[i])
-			}
-			if err := lu.SolveVecTo(colVec, false, aVec); err != nil {
-				// si la resolución falla, llenar con ceros y continuar
-				for i := 0; i < m; i++ {
-					tableRows[i][j] = 0
-				}
-			} else {
-				for i := 0; i < m; i++ {
-					tableRows[i][j] = colVec.AtVec(i)
+		// Con variables acotadas, una básica también puede llegar a su cota superior
+		// (d_i < 0) y la entrante puede llegar a la suya sin que cambie la base
+		toUpper := false
+		if flipped != nil {
+			for i := range m {
+				dv := dVec.AtVec(i)
+				if u := upper[baseVars[i]-1]; dv < -1e-12 && !math.IsInf(u, 1) {
+					ratio := (u - b.At(i, 0)) / -dv
+					if ratio < minRatio {
+						minRatio = ratio
+						leavingIndex = i
+						toUpper = true
+					}
 				}
 			}
 		}
-		// llenar columna RHS
-		for i := 0; i < m; i++ {
-			tableRows[i][totalVars] = b.At(i, 0)
+		boundFlip := !math.IsInf(upper[enteringVar-1], 1) && upper[enteringVar-1] <= minRatio
+
+		if leavingIndex == -1 && !boundFlip {
+			// Con una artificial positiva en la base, la entrante no tiene parte M
+			// positiva (si alguna columna la tuviera, habría entrado antes): ninguna
+			// columna reduce la infactibilidad y el problema es infactible aunque
+			// el de la gran M no esté acotado
+			if positiveArtificial(baseVars, artIndices, b) {
+				warning := "Problema infactible: no existe solución"
+				step.Status = StatusInfeasible
+				steps = append(steps, step)
+				return Result{Solution: basicSolution(n, baseVars, b), Steps: steps, Warning: warning, Variables: vars, Status: StatusInfeasible}
+			}
+			// La columna entrante no tiene elementos positivos: no hay pivote
+			warning := "Problema no acotado"
+			step.Status = StatusUnbounded
+			steps = append(steps, step)
+			return Result{Steps: steps, Warning: warning, Variables: vars, Status: StatusUnbounded}
 		}
 
-		// cb: coeficientes objetivo de variables básicas
-		cb := make([]float64, m)
-		for i := 0; i < m; i++ {
-			cb[i] = c.At(0, currentBaseVars[i]-1)
+		if boundFlip {
+			// La entrante llega a su cota superior antes que cualquier básica: la base
+			// no cambia y la variable se reemplaza por su complemento
+			theta := upper[enteringVar-1]
+			step.LeavingVar = enteringVar
+			step.LeavingLabel = step.EnteringLabel
+			step.TValue = theta
+			step.BoundFlip = true
+			steps = append(steps, step)
+			for i := range m {
+				b.SetVec(i, b.At(i, 0)-theta*dVec.AtVec(i))
+			}
+			complement(ATrans, c, flipped, enteringVar-1)
+			iter++
+			continue
 		}
 
-		step := SimplexStep{
-			Iteration:        iter,
-			BaseVariables:    currentBaseVars,
-			NonBaseVariables: currentNonBaseVars,
-			ReducedCosts:     reducedCosts,
-			BVector:          bVector,
-			EnteringVar:      newBaseVar,
-			LeavingVar:       leavingVar,
-			TValue:           lowestValueOfT,
-			Table:            tableRows,
-			Cj:               cj,
-			Cb:               cb,
-			PivotRow:         leavingIndex,
-			PivotCol:         enteringVar - 1,
-		}
+		leavingVar := baseVars[leavingIndex]
+		step.LeavingVar = leavingVar
+		step.LeavingLabel = label(vars, flipped, leavingVar)
+		step.TValue = minRatio
+		step.PivotRow = leavingIndex
 		steps = append(steps, step)
 
 		// Actualizar base: reemplazar baseVars[leavingIndex] con enteringVar
@@ -343,11 +291,190 @@ func SolveWithSigns(maximize mat.Vector, constraints *mat.Dense, signs []string)
 				b.SetVec(i, b.At(i, 0)-theta*dVec.AtVec(i))
 			}
 		}
+		// Si la saliente quedó en su cota superior pasa a no básica como complemento,
+		// así vuelve a valer 0; los valores de las básicas no cambian
+		if toUpper {
+			complement(ATrans, c, flipped, leavingVar-1)
+		}
 
 		iter++
 	}
-	warning := ""
-	return 0, nil, steps, warning
+	warning := "Se alcanzó el límite de iteraciones sin converger"
+	return Result{Steps: steps, Warning: warning, Variables: vars, Status: StatusIterationLimit}
+}
+
+// tableauStep arma el tableau completo de la iteración iter para la base actual:
+// B^{-1}A, la columna R, los coeficientes cj y cb, la fila zj, los costos relativos
+// cj - zj y el valor de la función objetivo. Si cM no es nil (hay artificiales),
+// también arma esos valores en forma simbólica a + b·M, donde la parte constante
+// sale de los coeficientes de las variables de decisión. No completa los datos del pivote.
+func tableauStep(iter int, vars []Variable, flipped []bool, ATrans *mat.Dense, c *mat.Dense, cM []float64, lu *mat.LU, b *mat.VecDense, baseVars, nonBase []int) SimplexStep {
+	m := b.Len()
+	totalVars := len(vars)
+
+	// Construir tableau completo: para cada columna de variable j, calcular B^{-1} * A[:, j]
+	// las filas del tableau contendrán coeficientes para cada variable y el RHS final
+	tableRows := make([][]float64, m)
+	for i := range m {
+		tableRows[i] = make([]float64, totalVars+1) // +1 para R
+	}
+	// encabezado cj
+	cj := make([]float64, totalVars)
+	for j := range totalVars {
+		cj[j] = c.At(0, j)
+		// resolver B^{-1} * A[:, j]
+		rawCol := ATrans.RawRowView(j)
+		colVec := mat.NewVecDense(m, nil)
+		aVec := mat.NewVecDense(m, nil)
+		for i := range m {
+			aVec.SetVec(i, rawCol[i])
+		}
+		if err := lu.SolveVecTo(colVec, false, aVec); err != nil {
+			// si la resolución falla, llenar con ceros y continuar
+			for i := range m {
+				tableRows[i][j] = 0
+			}
+		} else {
+			for i := range m {
+				tableRows[i][j] = colVec.AtVec(i)
+			}
+		}
+	}
+	// llenar columna RHS
+	for i := range m {
+		tableRows[i][totalVars] = b.At(i, 0)
+	}
+
+	// cb: coeficientes objetivo de variables básicas
+	cb := make([]float64, m)
+	for i := range m {
+		cb[i] = c.At(0, baseVars[i]-1)
+	}
+
+	// zj = cb · columna j del tableau; la última posición de la fila es Z = cb · R
+	zj := make([]float64, totalVars)
+	cjMinusZj := make([]float64, totalVars)
+	for j := range totalVars {
+		for i := range m {
+			zj[j] += cb[i] * tableRows[i][j]
+		}
+		cjMinusZj[j] = cj[j] - zj[j]
+	}
+	var z float64
+	for i := range m {
+		z += cb[i] * b.At(i, 0)
+	}
+
+	currentBaseVars := append([]int{}, baseVars...)
+	currentNonBaseVars := append([]int{}, nonBase...)
+	step := SimplexStep{
+		Iteration:        iter,
+		BaseVariables:    currentBaseVars,
+		NonBaseVariables: currentNonBaseVars,
+		BVector:          matVecToSlice(b),
+		Table:            tableRows,
+		Cj:               cj,
+		Cb:               cb,
+		Zj:               zj,
+		CjMinusZj:        cjMinusZj,
+		ObjectiveValue:   z,
+		PivotRow:         -1,
+		PivotCol:         -1,
+		ColumnLabels:     labels(vars, flipped, allColumns(totalVars)),
+		BaseLabels:       labels(vars, flipped, currentBaseVars),
+		NonBaseLabels:    labels(vars, flipped, currentNonBaseVars),
+	}
+	if cM != nil {
+		symbolicStep(&step, cM)
+	}
+	return step
+}
+
+// symbolicStep completa los campos simbólicos del paso separando en c la parte
+// proporcional a M (cM) de la constante. Como B^{-1}A no depende de c, zj se
+// descompone linealmente en las mismas dos partes.
+func symbolicStep(step *SimplexStep, cM []float64) {
+	totalVars := len(cM)
+	cjConst := make([]float64, totalVars)
+	for j := range totalVars {
+		if cM[j] == 0 {
+			cjConst[j] = step.Cj[j]
+		}
+	}
+	m := len(step.BaseVariables)
+	cbConst := make([]float64, m)
+	cbM := make([]float64, m)
+	for i, bv := range step.BaseVariables {
+		cbConst[i], cbM[i] = cjConst[bv-1], cM[bv-1]
+	}
+
+	zjConst := make([]float64, totalVars)
+	zjM := make([]float64, totalVars)
+	dConst := make([]float64, totalVars)
+	dM := make([]float64, totalVars)
+	for j := range totalVars {
+		for i := range m {
+			zjConst[j] += cbConst[i] * step.Table[i][j]
+			zjM[j] += cbM[i] * step.Table[i][j]
+		}
+		dConst[j] = cjConst[j] - zjConst[j]
+		dM[j] = cM[j] - zjM[j]
+	}
+	var z MValue
+	for i := range m {
+		z.Const += cbConst[i] * step.BVector[i]
+		z.M += cbM[i] * step.BVector[i]
+	}
+
+	step.CjM = mValues(cjConst, cM)
+	step.CbM = mValues(cbConst, cbM)
+	step.ZjM = mValues(zjConst, zjM)
+	step.CjMinusZjM = mValues(dConst, dM)
+	step.ObjectiveValueM = &z
+}
+
+// complement reemplaza la variable acotada j por su complemento x' = u - x: su
+// columna y su costo cambian de signo. Solo se acotan holguras y excesos, cuyo costo
+// es 0, por lo que el término constante c_j·u que aparece en el objetivo es nulo.
+func complement(ATrans, c *mat.Dense, flipped []bool, j int) {
+	row := ATrans.RawRowView(j)
+	for i := range row {
+		row[i] = -row[i]
+	}
+	c.Set(0, j, -c.At(0, j))
+	flipped[j] = !flipped[j]
+}
+
+// allColumns devuelve los índices base 1 de las n columnas extendidas.
+func allColumns(n int) []int {
+	out := make([]int, n)
+	for j := range n {
+		out[j] = j + 1
+	}
+	return out
+}
+
+// positiveArtificial indica si alguna variable artificial básica tiene valor
+// positivo. artIndices son índices base 0 y baseVars base 1.
+func positiveArtificial(baseVars, artIndices []int, b *mat.VecDense) bool {
+	for i, bv := range baseVars {
+		if contains(artIndices, bv-1) && b.AtVec(i) > 1e-9 {
+			return true
+		}
+	}
+	return false
+}
+
+// basicSolution devuelve los valores de las n variables de decisión en la base
+// actual; las no básicas valen 0.
+func basicSolution(n int, baseVars []int, b *mat.VecDense) []float64 {
+	solution := make([]float64, n)
+	for i, bv := range baseVars {
+		if bv-1 < n {
+			solution[bv-1] = b.AtVec(i)
+		}
+	}
+	return solution
 }
 
 func contains(s []int, e int) bool {
@@ -0,0 +1,162 @@
+package simplex
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func solveRange(t *testing.T, objective []float64, minimize bool, rows [][]float64, signs []string, lower []float64) Result {
+	t.Helper()
+	n := len(objective)
+	data := []float64{}
+	for _, r := range rows {
+		data = append(data, r...)
+	}
+	return SolveProblem(Problem{
+		Objective:   mat.NewVecDense(n, objective),
+		Minimize:    minimize,
+		Constraints: mat.NewDense(len(rows), n+1, data),
+		Signs:       signs,
+		Lower:       lower,
+	})
+}
+
+func TestSimplexRangeWithPositiveLower(t *testing.T) {
+	// Maximizar x1 + x2 con 2 <= x1 + x2 <= 5 y x1 <= 3
+	res := solveRange(t, []float64{1, 1}, false, [][]float64{
+		{1, 1, 5},
+		{1, 0, 3},
+	}, []string{"range", "<="}, []float64{2, 0})
+
+	if math.Abs(res.OptimalValue-5) > 1e-9 {
+		t.Fatalf("Expected optimal 5 but got %v (%s)", res.OptimalValue, res.Warning)
+	}
+	// La fila de rango es una sola: exceso acotado + artificial
+	if v := res.Variables[2]; v.Kind != Surplus || v.Upper == nil || *v.Upper != 3 {
+		t.Fatalf("Expected bounded surplus e1 <= 3 but got %+v", v)
+	}
+	if len(res.Variables) != 5 {
+		t.Fatalf("Expected 5 columns but got %d", len(res.Variables))
+	}
+}
+
+func TestSimplexRangeSlackReachesUpperBound(t *testing.T) {
+	// Maximizar x2 con -3 <= x1 - x2 <= 1 y x1 <= 2: la holgura s1 (0 <= s1 <= 4)
+	// llega a su cota superior y pasa a su complemento
+	res := solveRange(t, []float64{0, 1}, false, [][]float64{
+		{1, -1, 1},
+		{1, 0, 2},
+	}, []string{"range", "<="}, []float64{-3, 0})
+
+	if res.Status != StatusOptimal || math.Abs(res.OptimalValue-5) > 1e-9 {
+		t.Fatalf("Expected optimal 5 but got %v (%s)", res.OptimalValue, res.Warning)
+	}
+	if math.Abs(res.Solution[0]-2) > 1e-9 || math.Abs(res.Solution[1]-5) > 1e-9 {
+		t.Fatalf("Expected solution [2,5] but got %v", res.Solution)
+	}
+	complemented := false
+	for _, st := range res.Steps {
+		for _, l := range st.ColumnLabels {
+			if l == "s1'" {
+				complemented = true
+			}
+		}
+	}
+	if !complemented {
+		t.Fatalf("Expected s1 to be replaced by its complement in some tableau")
+	}
+}
+
+func TestSimplexRangeMatchesTwoRows(t *testing.T) {
+	// Cada fila de rango debe dar el mismo óptimo que escribirla como dos filas
+	// (a·x >= lo y a·x <= hi). Se recorren varios problemas pseudoaleatorios.
+	seed := uint64(7)
+	next := func() float64 {
+		seed = seed*6364136223846793005 + 1442695040888963407
+		return float64(int(seed>>33)%11 - 3)
+	}
+	flips := 0
+	for trial := range 200 {
+		n, m := 3, 3
+		objective := make([]float64, n)
+		for j := range objective {
+			objective[j] = next()
+		}
+		var rows, twoRows [][]float64
+		var signs, twoSigns []string
+		lower := make([]float64, m+1)
+		for i := range m {
+			row := make([]float64, n+1)
+			for j := range n {
+				row[j] = next()
+			}
+			lo := next()
+			hi := lo + math.Abs(next())
+			row[n] = hi
+			rows = append(rows, row)
+			signs = append(signs, "range")
+			lower[i] = lo
+
+			loRow := append([]float64{}, row...)
+			loRow[n] = lo
+			for k, r := range [][]float64{row, loRow} {
+				// El oráculo necesita lados derechos no negativos
+				sign := []string{"<=", ">="}[k]
+				r = append([]float64{}, r...)
+				if r[n] < 0 {
+					for j := range r {
+						r[j] = -r[j]
+					}
+					sign = map[string]string{"<=": ">=", ">=": "<="}[sign]
+				}
+				twoRows = append(twoRows, r)
+				twoSigns = append(twoSigns, sign)
+			}
+		}
+		// Una cota global para que el problema sea acotado
+		box := []float64{1, 1, 1, 10}
+		rows = append(rows, box)
+		signs = append(signs, "<=")
+		twoRows = append(twoRows, box)
+		twoSigns = append(twoSigns, "<=")
+
+		minimize := trial%2 == 1
+		got := solveRange(t, objective, minimize, rows, signs, lower)
+		want := solveRange(t, objective, minimize, twoRows, twoSigns, nil)
+		if got.Status != want.Status {
+			t.Fatalf("trial %d: expected status %q but got %q (rows %v, lower %v)", trial, want.Status, got.Status, rows, lower)
+		}
+		if want.Status == StatusOptimal && math.Abs(got.OptimalValue-want.OptimalValue) > 1e-6 {
+			t.Fatalf("trial %d: expected optimal %v but got %v", trial, want.OptimalValue, got.OptimalValue)
+		}
+		for _, st := range got.Steps {
+			if st.BoundFlip {
+				flips++
+			}
+		}
+	}
+	if flips == 0 {
+		t.Fatalf("Expected at least one bound flip across trials")
+	}
+}
+
+func TestSimplexRangeNegativeUpper(t *testing.T) {
+	// Maximizar -x1 con -5 <= -x1 <= -2: la fila se multiplica por -1
+	res := solveRange(t, []float64{-1}, false, [][]float64{
+		{-1, -2},
+	}, []string{"range"}, []float64{-5})
+
+	if math.Abs(res.OptimalValue+2) > 1e-9 {
+		t.Fatalf("Expected optimal -2 but got %v (%s)", res.OptimalValue, res.Warning)
+	}
+
+	// Minimizar -x1 con la misma fila: el óptimo es la cota superior x1 = 5
+	res = solveRange(t, []float64{-1}, true, [][]float64{
+		{-1, -2},
+	}, []string{"range"}, []float64{-5})
+	if math.Abs(res.OptimalValue+5) > 1e-9 {
+		t.Fatalf("Expected optimal -5 but got %v (%s)", res.OptimalValue, res.Warning)
+	}
+}
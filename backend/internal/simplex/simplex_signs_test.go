@@ -1,6 +1,8 @@
 package simplex
 
 import (
+	"math"
+	"strings"
 	"testing"
 
 	"gonum.org/v1/gonum/mat"
@@ -60,3 +62,185 @@ func TestSimplexGreaterEqualConstraint(t *testing.T) {
 		t.Fatalf("Expected solution [2,3] but got %v", sol)
 	}
 }
+
+func TestSimplexVariableCatalog(t *testing.T) {
+	// Maximizar 2 x1 + 1 x2 con una fila de cada tipo
+	maximize := mat.NewVecDense(2, []float64{2, 1})
+	constraints := mat.NewDense(3, 3, []float64{
+		1, 1, 3,
+		1, 0, 2,
+		0, 1, 3,
+	})
+	signs := []string{"<=", ">=", "="}
+
+	res := SolveProblem(Problem{Objective: maximize, Constraints: constraints, Signs: signs})
+
+	expected := []Variable{
+		{Index: 1, Kind: Decision, Row: -1, Name: "x1"},
+		{Index: 2, Kind: Decision, Row: -1, Name: "x2"},
+		{Index: 3, Kind: Slack, Row: 0, Name: "s1"},
+		{Index: 4, Kind: Surplus, Row: 1, Name: "e2"},
+		{Index: 5, Kind: Artificial, Row: 1, Name: "a2"},
+		{Index: 6, Kind: Artificial, Row: 2, Name: "a3"},
+	}
+	if len(res.Variables) != len(expected) {
+		t.Fatalf("Expected %d variables but got %v", len(expected), res.Variables)
+	}
+	for i := range expected {
+		if res.Variables[i] != expected[i] {
+			t.Fatalf("Variable %d: expected %+v but got %+v", i, expected[i], res.Variables[i])
+		}
+	}
+
+	if len(res.Steps) == 0 {
+		t.Fatalf("Expected at least one step")
+	}
+	first := res.Steps[0]
+	if got := first.BaseLabels; len(got) != 3 || got[0] != "s1" || got[1] != "a2" || got[2] != "a3" {
+		t.Fatalf("Unexpected initial base labels: %v", got)
+	}
+	if len(first.ColumnLabels) != len(expected) {
+		t.Fatalf("Unexpected column labels: %v", first.ColumnLabels)
+	}
+}
+
+func TestSimplexNamedVariables(t *testing.T) {
+	// Mismo problema con nombres: la segunda fila queda con su nombre por defecto
+	maximize := mat.NewVecDense(2, []float64{2, 1})
+	constraints := mat.NewDense(3, 3, []float64{
+		1, 1, 3,
+		1, 0, 2,
+		0, 1, 3,
+	})
+	res := SolveProblem(Problem{
+		Objective:   maximize,
+		Constraints: constraints,
+		Signs:       []string{"<=", ">=", "="},
+		VarNames:    []string{"mesas", "sillas"},
+		RowNames:    []string{"madera", "", "pedido"},
+	})
+
+	names := make([]string, len(res.Variables))
+	for i, v := range res.Variables {
+		names[i] = v.Name
+	}
+	expected := []string{"mesas", "sillas", "s_madera", "e2", "a2", "a_pedido"}
+	for i := range expected {
+		if i >= len(names) || names[i] != expected[i] {
+			t.Fatalf("Expected names %v but got %v", expected, names)
+		}
+	}
+	if got := res.Steps[0].BaseLabels; len(got) != 3 || got[0] != "s_madera" || got[2] != "a_pedido" {
+		t.Fatalf("Unexpected initial base labels: %v", got)
+	}
+
+	values := res.Values()
+	if len(values) != 2 || math.Abs(values["mesas"]-res.Solution[0]) > 1e-9 || math.Abs(values["sillas"]-res.Solution[1]) > 1e-9 {
+		t.Fatalf("Unexpected values %v for solution %v", values, res.Solution)
+	}
+}
+
+func TestSimplexSymbolicBigM(t *testing.T) {
+	cases := map[MValue]string{
+		{Const: 0, M: 0}:  "0",
+		{Const: 5, M: 0}:  "5",
+		{Const: 0, M: 1}:  "M",
+		{Const: 0, M: -2}: "−2M",
+		{Const: 3, M: -2}: "3 − 2M",
+		{Const: -1, M: 1}: "−1 + M",
+	}
+	for v, expected := range cases {
+		if got := v.String(); got != expected {
+			t.Fatalf("Expected %q but got %q for %+v", expected, got, v)
+		}
+	}
+
+	// FormatValues usa la forma simbólica solo si hay un MValue por valor
+	if got := FormatValues([]float64{1.23456, -2, 0}, nil); strings.Join(got, " ") != "1.2346 −2 0" {
+		t.Fatalf("Unexpected plain values %q", got)
+	}
+	if got := FormatValues([]float64{0, 0}, []MValue{{Const: 3, M: -2}, {M: 1}}); strings.Join(got, " ") != "3 − 2M M" {
+		t.Fatalf("Unexpected symbolic values %q", got)
+	}
+
+	// Maximizar 3 x1 + 2 x2 sujeto a x1 + x2 = 4: la tabla inicial tiene a1 en la base
+	maximize := mat.NewVecDense(2, []float64{3, 2})
+	constraints := mat.NewDense(1, 3, []float64{1, 1, 4})
+	res := SolveProblem(Problem{Objective: maximize, Constraints: constraints, Signs: []string{"="}})
+
+	first := res.Steps[0]
+	if len(first.CjMinusZjM) != 3 || first.ObjectiveValueM == nil {
+		t.Fatalf("Expected symbolic values in first step: %+v", first)
+	}
+	expected := []string{"3 + M", "2 + M", "0"}
+	for j, e := range expected {
+		if got := first.CjMinusZjM[j].String(); got != e {
+			t.Fatalf("cj-zj[%d]: expected %q but got %q", j, e, got)
+		}
+	}
+	if got := first.ObjectiveValueM.String(); got != "−4M" {
+		t.Fatalf("Expected Z = −4M but got %q", got)
+	}
+}
+
+func TestSimplexNativeMinimize(t *testing.T) {
+	// Minimizar 4 x1 + 5 x2
+	objective := mat.NewVecDense(2, []float64{4, 5})
+
+	// Restricciones:
+	// 2 x1 + x2 >= 8
+	// x1 + 3 x2 >= 12
+	constraints := mat.NewDense(2, 3, []float64{
+		2, 1, 8,
+		1, 3, 12,
+	})
+
+	res := SolveProblem(Problem{Objective: objective, Minimize: true, Constraints: constraints, Signs: []string{">=", ">="}})
+
+	if math.Abs(res.OptimalValue-25.6) > 1e-9 {
+		t.Fatalf("Expected optimal 25.6 but got %v, solution: %v", res.OptimalValue, res.Solution)
+	}
+
+	// Las tablas muestran los coeficientes originales y las artificiales con +M
+	first := res.Steps[0]
+	if first.Cj[0] != 4 || first.Cj[1] != 5 {
+		t.Fatalf("Expected original coefficients in cj but got %v", first.Cj)
+	}
+	if got := first.CjM[3].String(); got != "M" {
+		t.Fatalf("Expected artificial cost M but got %q", got)
+	}
+
+	// Criterio de optimalidad de minimización: ningún cj - zj negativo
+	last := res.Steps[len(res.Steps)-1]
+	if last.Status != StatusOptimal {
+		t.Fatalf("Expected optimal final step but got %q", last.Status)
+	}
+	for j, v := range last.CjMinusZj {
+		if v < -1e-9 {
+			t.Fatalf("Final tableau is not optimal for minimization: cj-zj[%d] = %f", j, v)
+		}
+	}
+	if math.Abs(last.ObjectiveValue-25.6) > 1e-9 {
+		t.Fatalf("Expected final Z 25.6 but got %v", last.ObjectiveValue)
+	}
+}
+
+func TestSimplexInfeasibleWithUnboundedColumn(t *testing.T) {
+	// Maximizar 4 x1 + x2 + 3 x3 con -x1 - x3 >= 1 (imposible con x >= 0):
+	// x2 no aparece en las restricciones, así que el problema de la gran M no
+	// está acotado, pero la artificial de la primera fila sigue en la base
+	res := SolveProblem(Problem{
+		Objective: mat.NewVecDense(3, []float64{4, 1, 3}),
+		Constraints: mat.NewDense(2, 4, []float64{
+			-1, 0, -1, 1,
+			2, 0, 5, 8,
+		}),
+		Signs: []string{">=", ">="},
+	})
+	if res.Status != StatusInfeasible {
+		t.Fatalf("Expected status %q but got %q (%s)", StatusInfeasible, res.Status, res.Warning)
+	}
+	if last := res.Steps[len(res.Steps)-1]; last.Status != StatusInfeasible {
+		t.Fatalf("Expected last step status %q but got %q", StatusInfeasible, last.Status)
+	}
+}
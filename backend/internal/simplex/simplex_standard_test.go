@@ -0,0 +1,92 @@
+package simplex
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestStandardize(t *testing.T) {
+	// Maximizar 3 x1 + 5 x2
+	// x1 <= 4
+	// -3 x1 - 2 x2 <= -18  (se multiplica por -1 y queda >= 18)
+	// x1 + x2 = 7
+	sf := Standardize(Problem{
+		Objective: mat.NewVecDense(2, []float64{3, 5}),
+		Constraints: mat.NewDense(3, 3, []float64{
+			1, 0, 4,
+			-3, -2, -18,
+			1, 1, 7,
+		}),
+		Signs:    []string{"<=", "<=", "="},
+		RowNames: []string{"", "demanda", ""},
+	})
+
+	names := make([]string, len(sf.Variables))
+	for i, v := range sf.Variables {
+		names[i] = v.Name
+	}
+	if want := []string{"x1", "x2", "s1", "e_demanda", "a_demanda", "a3"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Expected variables %v but got %v", want, names)
+	}
+
+	r := sf.Rows[1]
+	if r.Name != "demanda" || !r.Negated || r.RHS != -18 || r.StandardRHS != 18 {
+		t.Fatalf("Unexpected negated row: %+v", r)
+	}
+	if !reflect.DeepEqual(r.AddedLabels, []string{"e_demanda", "a_demanda"}) || r.BasicLabel != "a_demanda" {
+		t.Fatalf("Unexpected added variables: %v, basic %q", r.AddedLabels, r.BasicLabel)
+	}
+	if !strings.Contains(r.Explanation, "se multiplica la fila por −1 y el signo <= pasa a >=") {
+		t.Fatalf("Unexpected explanation: %q", r.Explanation)
+	}
+	if sf.Rows[0].Negated || sf.Rows[0].BasicLabel != "s1" || sf.Rows[2].Name != "R3" {
+		t.Fatalf("Unexpected rows: %+v", sf.Rows)
+	}
+
+	if want := []float64{3, 2, 0, -1, 1, 0, 18}; !reflect.DeepEqual(sf.Table[1], want) {
+		t.Fatalf("Expected row %v but got %v", want, sf.Table[1])
+	}
+	objective := make([]string, len(sf.Objective))
+	for i, v := range sf.Objective {
+		objective[i] = v.String()
+	}
+	if want := []string{"3", "5", "0", "0", "−M", "−M"}; !reflect.DeepEqual(objective, want) {
+		t.Fatalf("Expected objective %v but got %v", want, objective)
+	}
+	if !reflect.DeepEqual(sf.BasisLabels, []string{"s1", "a_demanda", "a3"}) || !reflect.DeepEqual(sf.Values, []float64{4, 18, 7}) {
+		t.Fatalf("Unexpected initial basis %v = %v", sf.BasisLabels, sf.Values)
+	}
+	if sf.ObjectiveValue != (MValue{M: -25}) {
+		t.Fatalf("Expected initial Z = −25M but got %v", sf.ObjectiveValue)
+	}
+
+	// La primera tabla del simplex arranca de la misma base
+	res := SolveProblem(Problem{
+		Objective:   mat.NewVecDense(2, []float64{3, 5}),
+		Constraints: mat.NewDense(3, 3, []float64{1, 0, 4, -3, -2, -18, 1, 1, 7}),
+		Signs:       []string{"<=", "<=", "="},
+	})
+	if !reflect.DeepEqual(res.Steps[0].BaseVariables, sf.Basis) {
+		t.Fatalf("Expected first basis %v but got %v", sf.Basis, res.Steps[0].BaseVariables)
+	}
+}
+
+func TestSimplexNegativeRHS(t *testing.T) {
+	// Maximizar -x1 con x1 <= 4 y -x1 <= -1 (x1 >= 1): el óptimo es x1 = 1
+	res := SolveProblem(Problem{
+		Objective:   mat.NewVecDense(1, []float64{-1}),
+		Constraints: mat.NewDense(2, 2, []float64{1, 4, -1, -1}),
+	})
+	if res.Status != StatusOptimal || res.OptimalValue != -1 || res.Solution[0] != 1 {
+		t.Fatalf("Expected optimal -1 at x1 = 1 but got %s %v %v", res.Status, res.OptimalValue, res.Solution)
+	}
+	if kind := Catalog(Problem{
+		Objective:   mat.NewVecDense(1, []float64{-1}),
+		Constraints: mat.NewDense(2, 2, []float64{1, 4, -1, -1}),
+	})[2].Kind; kind != Surplus {
+		t.Fatalf("Expected a surplus for the negated row but got %s", kind)
+	}
+}
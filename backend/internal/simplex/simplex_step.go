@@ -16,7 +16,33 @@ type SimplexStep struct {
 	Cj []float64 `json:"cj,omitempty"`
 	// Cb: coeficientes objetivo de las variables básicas (uno por fila)
 	Cb []float64 `json:"cb,omitempty"`
-	// Pivot position: fila (0-based) y columna (0-based dentro de variables extendidas)
-	PivotRow int `json:"pivot_row,omitempty"`
-	PivotCol int `json:"pivot_col,omitempty"`
+	// Zj: fila z_j = c_b · (columna j del tableau), una por variable extendida
+	Zj []float64 `json:"zj,omitempty"`
+	// CjMinusZj: costos relativos c_j - z_j, uno por variable extendida
+	CjMinusZj []float64 `json:"cj_minus_zj,omitempty"`
+	// ObjectiveValue: valor Z = c_b · R de la solución básica de esta iteración
+	ObjectiveValue float64 `json:"objective_value"`
+	// Los mismos valores en forma simbólica a + b·M; presentes solo cuando el
+	// problema tiene variables artificiales (método de la gran M)
+	CjM             []MValue `json:"cj_m,omitempty"`
+	CbM             []MValue `json:"cb_m,omitempty"`
+	ZjM             []MValue `json:"zj_m,omitempty"`
+	CjMinusZjM      []MValue `json:"cj_minus_zj_m,omitempty"`
+	ObjectiveValueM *MValue  `json:"objective_value_m,omitempty"`
+	// BoundFlip indica que la entrante llegó a su cota superior antes que cualquier
+	// básica: la base no cambia y la variable pasa a su complemento (u - x)
+	BoundFlip bool `json:"bound_flip,omitempty"`
+	// Status: veredicto del algoritmo, presente solo en la última iteración
+	Status Status `json:"status,omitempty"`
+	// Pivot position: fila (0-based) y columna (0-based dentro de variables extendidas).
+	// Valen -1 cuando la iteración no pivotea (tableau final).
+	PivotRow int `json:"pivot_row"`
+	PivotCol int `json:"pivot_col"`
+
+	// Nombres de las variables según el catálogo (x1, s2, e3, a3)
+	ColumnLabels  []string `json:"column_labels,omitempty"`
+	BaseLabels    []string `json:"base_labels,omitempty"`
+	NonBaseLabels []string `json:"non_base_labels,omitempty"`
+	EnteringLabel string   `json:"entering_label,omitempty"`
+	LeavingLabel  string   `json:"leaving_label,omitempty"`
 }
@@ -1,6 +1,8 @@
 package simplex
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"gonum.org/v1/gonum/mat"
@@ -72,3 +74,58 @@ func TestSimplexFractions(t *testing.T) {
 		t.Fatalf("Expected %v but got %v", expectedSolution, actualSolution)
 	}
 }
+
+func TestSimplexFinalTableau(t *testing.T) {
+	maximize := mat.NewVecDense(3, []float64{5, 4, 3})
+
+	constraints := mat.NewDense(3, 4, []float64{
+		2, 3, 1, 5,
+		4, 1, 2, 11,
+		3, 4, 2, 8})
+
+	res := SolveProblem(Problem{Objective: maximize, Constraints: constraints})
+	if res.Status != StatusOptimal {
+		t.Fatalf("Expected status %q but got %q", StatusOptimal, res.Status)
+	}
+	if len(res.Steps) == 0 {
+		t.Fatalf("Expected steps")
+	}
+
+	// La primera tabla parte de la base de holguras con Z = 0
+	if res.Steps[0].ObjectiveValue != 0 {
+		t.Fatalf("Expected initial Z = 0 but got %f", res.Steps[0].ObjectiveValue)
+	}
+
+	// La última tabla es la óptima: no pivotea y lleva el veredicto
+	last := res.Steps[len(res.Steps)-1]
+	if last.Status != StatusOptimal || last.PivotRow != -1 || last.EnteringVar != 0 {
+		t.Fatalf("Unexpected final step: %+v", last)
+	}
+	if last.ObjectiveValue != res.OptimalValue {
+		t.Fatalf("Expected final Z %f but got %f", res.OptimalValue, last.ObjectiveValue)
+	}
+	for j, v := range last.CjMinusZj {
+		if v > 1e-9 {
+			t.Fatalf("Final tableau is not optimal: cj-zj[%d] = %f", j, v)
+		}
+		if diff := last.Cj[j] - last.Zj[j] - v; diff > 1e-9 || diff < -1e-9 {
+			t.Fatalf("cj-zj[%d] inconsistent with cj and zj", j)
+		}
+	}
+	for _, st := range res.Steps[:len(res.Steps)-1] {
+		if st.Status != "" {
+			t.Fatalf("Intermediate step %d should not carry a status", st.Iteration)
+		}
+	}
+}
+
+func TestSimplexStepPivotJSON(t *testing.T) {
+	// Un pivote en la fila 0 y la columna 0 también se envía: -1 es "sin pivote"
+	data, err := json.Marshal(SimplexStep{PivotRow: 0, PivotCol: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"pivot_row":0`) || !strings.Contains(string(data), `"pivot_col":0`) {
+		t.Fatalf("Expected pivot_row and pivot_col in %s", data)
+	}
+}
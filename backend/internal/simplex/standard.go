@@ -0,0 +1,285 @@
+package simplex
+
+import (
+	"fmt"
+	"strings"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// form es la forma estándar con la que arranca SolveProblem.
+type form struct {
+	vars []Variable
+	// scale es el factor (1 o -1) por el que se multiplicó cada fila.
+	scale []float64
+	A     *mat.Dense
+	b     *mat.VecDense
+	// c incluye la penalidad numérica de las artificiales; cM es su componente
+	// en M (nil si no hay artificiales).
+	c  *mat.Dense
+	cM []float64
+	// baseVars tiene la variable básica inicial (base 1) de cada fila.
+	baseVars   []int
+	artIndices []int
+}
+
+// rowSign devuelve el signo con que la fila i entra en la forma estándar, el
+// factor por el que se multiplica y el lado derecho resultante. Una fila con lado
+// derecho negativo se multiplica por -1 (<= pasa a >= y viceversa) para que la
+// base inicial no tenga valores negativos; las filas de rango se resuelven con
+// rangeRow y conservan el signo "range".
+func rowSign(p Problem, i int) (sign string, scale, rhs float64) {
+	_, cols := p.Constraints.Dims()
+	sign, rhs = signAt(p.Signs, i), p.Constraints.At(i, cols-1)
+	if sign == "range" {
+		scale, rhs, _ = rangeRow(lowerAt(p.Lower, i), rhs)
+		return sign, scale, rhs
+	}
+	if rhs >= 0 {
+		return sign, 1, rhs
+	}
+	switch sign {
+	case ">=":
+		sign = "<="
+	case "=":
+	default:
+		sign = ">="
+	}
+	return sign, -1, -rhs
+}
+
+// buildForm arma la matriz extendida de p: holgura (para <=), exceso y
+// artificial (para >=), artificial (para =) y holgura o exceso acotados (para
+// rangos). Las artificiales se penalizan con -M al maximizar y +M al minimizar.
+func buildForm(p Problem, M float64) form {
+	n := p.Objective.Len()
+	m, _ := p.Constraints.Dims()
+	sense := 1.0
+	if p.Minimize {
+		sense = -1
+	}
+	vars := Catalog(p)
+	totalVars := len(vars)
+
+	// Lado derecho y factor de cada fila (ver rowSign)
+	scale := make([]float64, m)
+	bData := make([]float64, m)
+	for i := range m {
+		_, scale[i], bData[i] = rowSign(p, i)
+	}
+
+	// Construir A_extendida
+	A := mat.NewDense(m, totalVars, nil)
+	// Llenar variables originales
+	for i := range m {
+		for j := range n {
+			A.Set(i, j, scale[i]*p.Constraints.At(i, j))
+		}
+	}
+
+	// Rastrear índices y variable base por fila. La variable base inicial de cada
+	// fila es su holgura o, si la tiene, su artificial (la última columna agregada).
+	baseVars := make([]int, m) // índices base 1 de variables básicas por fila
+	artIndices := []int{}
+	for _, v := range vars[n:] {
+		switch v.Kind {
+		case Surplus:
+			A.Set(v.Row, v.Index-1, -1)
+		case Artificial:
+			artIndices = append(artIndices, v.Index-1)
+			fallthrough
+		default:
+			A.Set(v.Row, v.Index-1, 1)
+		}
+		baseVars[v.Row] = v.Index
+	}
+
+	// Construir objetivo c (1 x totalVars)
+	c := mat.NewDense(1, totalVars, make([]float64, totalVars))
+	for j := range n {
+		c.Set(0, j, p.Objective.At(j, 0))
+	}
+	for _, ai := range artIndices {
+		c.Set(0, ai, -sense*M)
+	}
+	// Componente M de c por separado, para mostrar los valores como a + b·M
+	var cM []float64
+	if len(artIndices) > 0 {
+		cM = make([]float64, totalVars)
+		for _, ai := range artIndices {
+			cM[ai] = -sense
+		}
+	}
+
+	return form{
+		vars: vars, scale: scale, A: A, b: mat.NewVecDense(m, bData),
+		c: c, cM: cM, baseVars: baseVars, artIndices: artIndices,
+	}
+}
+
+// StandardForm describe paso a paso cómo SolveProblem lleva el problema a la
+// forma estándar antes de la primera tabla.
+type StandardForm struct {
+	Minimize bool `json:"minimize"`
+	// Rows explica qué se hizo con cada restricción.
+	Rows []RowForm `json:"rows"`
+	// Variables son las columnas de la matriz extendida.
+	Variables []Variable `json:"variables"`
+	// Objective es el cj de cada columna, con la penalidad de las artificiales.
+	Objective []MValue `json:"objective"`
+	// Table es la matriz extendida [A | b], una fila por restricción.
+	Table [][]float64 `json:"table"`
+	// Basis y BasisLabels son la base inicial (índices base 1), una variable por
+	// fila, y Values sus valores.
+	Basis       []int     `json:"basis"`
+	BasisLabels []string  `json:"basis_labels"`
+	Values      []float64 `json:"values"`
+	// ObjectiveValue es Z en la base inicial: la suma de cb·b.
+	ObjectiveValue MValue `json:"objective_value"`
+}
+
+// RowForm es la transformación de una restricción.
+type RowForm struct {
+	// Row es el índice base 0 de la restricción y Name su nombre (R1, R2... si no
+	// fue nombrada).
+	Row  int    `json:"row"`
+	Name string `json:"name"`
+	// Sign, Coefficients, RHS y Lower son la restricción tal como fue ingresada.
+	Sign         string    `json:"sign"`
+	Coefficients []float64 `json:"coefficients"`
+	RHS          float64   `json:"rhs"`
+	Lower        *float64  `json:"lower,omitempty"`
+	// Negated indica que la fila se multiplicó por -1.
+	Negated bool `json:"negated"`
+	// StandardRHS es el lado derecho de la igualdad resultante.
+	StandardRHS float64 `json:"standard_rhs"`
+	// Added son las variables agregadas a la fila (índices base 1).
+	Added       []int    `json:"added"`
+	AddedLabels []string `json:"added_labels"`
+	// Basic es la variable básica inicial de la fila.
+	Basic      int    `json:"basic"`
+	BasicLabel string `json:"basic_label"`
+	// Explanation cuenta la transformación en una oración.
+	Explanation string `json:"explanation"`
+}
+
+// Standardize devuelve la transformación de p a la forma estándar que usa
+// SolveProblem: el cambio de signo de las filas con lado derecho negativo, las
+// holguras, excesos y artificiales de cada fila, el objetivo con la penalidad M
+// y la base inicial.
+func Standardize(p Problem) StandardForm {
+	const M = 1e7
+	f := buildForm(p, M)
+	m, cols := p.Constraints.Dims()
+	n := p.Objective.Len()
+	total := len(f.vars)
+
+	out := StandardForm{Minimize: p.Minimize, Variables: f.vars}
+	for j := range total {
+		v := MValue{Const: f.c.At(0, j)}
+		if f.cM != nil && f.cM[j] != 0 {
+			v = MValue{M: f.cM[j]}
+		}
+		out.Objective = append(out.Objective, v)
+	}
+	for i := range m {
+		row := make([]float64, total+1)
+		for j := range total {
+			row[j] = f.A.At(i, j)
+		}
+		row[total] = f.b.AtVec(i)
+		out.Table = append(out.Table, row)
+	}
+	out.Basis = f.baseVars
+	out.BasisLabels = labels(f.vars, nil, f.baseVars)
+	out.Values = matVecToSlice(f.b)
+	for i, bv := range f.baseVars {
+		out.ObjectiveValue.Const += out.Objective[bv-1].Const * out.Values[i]
+		out.ObjectiveValue.M += out.Objective[bv-1].M * out.Values[i]
+	}
+
+	for i := range m {
+		sign, scale, rhs := rowSign(p, i)
+		r := RowForm{
+			Row:          i,
+			Name:         nameAt(p.RowNames, i, fmt.Sprintf("R%d", i+1)),
+			Sign:         signAt(p.Signs, i),
+			Coefficients: make([]float64, n),
+			RHS:          p.Constraints.At(i, cols-1),
+			Negated:      scale < 0,
+			StandardRHS:  rhs,
+			Added:        []int{},
+			AddedLabels:  []string{},
+			Basic:        f.baseVars[i],
+			BasicLabel:   label(f.vars, nil, f.baseVars[i]),
+		}
+		for j := range n {
+			r.Coefficients[j] = p.Constraints.At(i, j)
+		}
+		if r.Sign == "range" {
+			lo := lowerAt(p.Lower, i)
+			r.Lower = &lo
+		}
+		for _, v := range f.vars[n:] {
+			if v.Row == i {
+				r.Added = append(r.Added, v.Index)
+				r.AddedLabels = append(r.AddedLabels, v.Name)
+			}
+		}
+		r.Explanation = explain(r, sign, f.vars, p.Minimize)
+		out.Rows = append(out.Rows, r)
+	}
+	return out
+}
+
+// explain describe en una oración la transformación de la fila r, que entra en
+// la forma estándar con el signo sign.
+func explain(r RowForm, sign string, vars []Variable, minimize bool) string {
+	var parts []string
+	penalty := "−M"
+	if minimize {
+		penalty = "+M"
+	}
+	if r.Negated {
+		switch sign {
+		case "=":
+			parts = append(parts, "El lado derecho es negativo: se multiplica la fila por −1")
+		case "range":
+			parts = append(parts, fmt.Sprintf("Las dos cotas son negativas: se multiplica la fila por −1 y queda %s <= −a·x <= %s",
+				FormatNumber(r.StandardRHS), FormatNumber(-*r.Lower)))
+		default:
+			parts = append(parts, fmt.Sprintf("El lado derecho es negativo: se multiplica la fila por −1 y el signo %s pasa a %s", r.Sign, sign))
+		}
+	}
+
+	var slack, surplus, artificial string
+	for _, idx := range r.Added {
+		switch v := vars[idx-1]; v.Kind {
+		case Slack:
+			slack = v.Name
+		case Surplus:
+			surplus = v.Name
+		case Artificial:
+			artificial = v.Name
+		}
+	}
+	switch {
+	case slack != "" && sign == "range":
+		parts = append(parts, fmt.Sprintf("se suma la holgura %s, acotada por %s, que entra en la base inicial",
+			slack, FormatNumber(r.RHS-*r.Lower)))
+	case slack != "":
+		parts = append(parts, fmt.Sprintf("se suma la holgura %s, que entra en la base inicial", slack))
+	case surplus != "" && sign == "range":
+		parts = append(parts, fmt.Sprintf("el origen no cumple la cota inferior: se resta el exceso %s, acotado por %s, y se suma la artificial %s con costo %s, que entra en la base inicial",
+			surplus, FormatNumber(r.RHS-*r.Lower), artificial, penalty))
+	case surplus != "":
+		parts = append(parts, fmt.Sprintf("se resta el exceso %s y, como %s no puede ser básica con valor negativo, se suma la artificial %s con costo %s, que entra en la base inicial",
+			surplus, surplus, artificial, penalty))
+	default:
+		parts = append(parts, fmt.Sprintf("una igualdad no lleva holgura: se suma la artificial %s con costo %s, que entra en la base inicial",
+			artificial, penalty))
+	}
+
+	s := strings.Join(parts, "; ")
+	return strings.ToUpper(s[:1]) + s[1:] + "."
+}
@@ -0,0 +1,159 @@
+package simplex
+
+import "fmt"
+
+// VariableKind clasifica cada columna de la matriz extendida.
+type VariableKind string
+
+const (
+	Decision   VariableKind = "decision"
+	Slack      VariableKind = "slack"
+	Surplus    VariableKind = "surplus"
+	Artificial VariableKind = "artificial"
+)
+
+// Variable describe una columna de la matriz extendida que arma el solver.
+type Variable struct {
+	// Index es el índice base 1 de la columna, el mismo que usan BaseVariables y EnteringVar.
+	Index int          `json:"index"`
+	Kind  VariableKind `json:"kind"`
+	// Row es la restricción (base 0) que originó la variable; -1 para variables de decisión.
+	Row  int    `json:"row"`
+	Name string `json:"name"`
+	// Upper es la cota superior de la holgura (o exceso) de una fila de rango
+	// lo <= a·x <= hi, igual a hi - lo; nil si la variable no está acotada.
+	Upper *float64 `json:"upper,omitempty"`
+}
+
+// Catalog devuelve las variables de la matriz extendida de p en el mismo orden en
+// que SolveProblem agrega las columnas: primero las n variables de decisión y luego,
+// por cada restricción, su holgura (<=), exceso y artificial (>=) o artificial (=).
+// Una fila con lado derecho negativo cuenta con el signo invertido (ver rowSign).
+// Una fila de rango lleva una holgura acotada o, si el origen no la satisface,
+// un exceso acotado y una artificial (ver rangeRow). Los nombres de las
+// auxiliares salen de AuxName.
+func Catalog(p Problem) []Variable {
+	n := p.Objective.Len()
+	rows, cols := p.Constraints.Dims()
+	vars := make([]Variable, 0, n+2*rows)
+	used := make(map[string]bool, n+2*rows)
+	add := func(kind VariableKind, row int, name string) {
+		vars = append(vars, Variable{Index: len(vars) + 1, Kind: kind, Row: row, Name: name})
+		used[name] = true
+	}
+	for j := range n {
+		add(Decision, -1, nameAt(p.VarNames, j, fmt.Sprintf("x%d", j+1)))
+	}
+	for i := range rows {
+		aux := func(prefix string) string { return AuxName(used, p.RowNames, i, prefix) }
+		switch sign, _, _ := rowSign(p, i); sign {
+		case "range":
+			lo, hi := lowerAt(p.Lower, i), p.Constraints.At(i, cols-1)
+			width := hi - lo
+			if _, _, artificial := rangeRow(lo, hi); artificial {
+				add(Surplus, i, aux("e"))
+				vars[len(vars)-1].Upper = &width
+				add(Artificial, i, aux("a"))
+			} else {
+				add(Slack, i, aux("s"))
+				vars[len(vars)-1].Upper = &width
+			}
+		case ">=":
+			add(Surplus, i, aux("e"))
+			add(Artificial, i, aux("a"))
+		case "=":
+			add(Artificial, i, aux("a"))
+		default:
+			add(Slack, i, aux("s"))
+		}
+	}
+	return vars
+}
+
+// AuxName devuelve el nombre de la variable auxiliar de la fila i con el prefijo
+// s, e o a: s_cap si la fila se llama "cap" o s3 si no tiene nombre. Si el nombre
+// ya está en used (por ejemplo, una variable de decisión llamada s3) se le
+// agregan guiones bajos hasta que sea único.
+func AuxName(used map[string]bool, rowNames []string, i int, prefix string) string {
+	name := fmt.Sprintf("%s%d", prefix, i+1)
+	if row := nameAt(rowNames, i, ""); row != "" {
+		name = prefix + "_" + row
+	}
+	for used[name] {
+		name += "_"
+	}
+	return name
+}
+
+// nameAt devuelve names[i] si fue informado y no está vacío, o def en otro caso.
+func nameAt(names []string, i int, def string) string {
+	if i < len(names) && names[i] != "" {
+		return names[i]
+	}
+	return def
+}
+
+// Values asocia el nombre de cada variable de decisión con su valor en la solución.
+func (r Result) Values() map[string]float64 {
+	out := make(map[string]float64, len(r.Solution))
+	for _, v := range r.Variables {
+		if v.Kind == Decision && v.Index <= len(r.Solution) {
+			out[v.Name] = r.Solution[v.Index-1]
+		}
+	}
+	return out
+}
+
+// signAt devuelve el signo de la fila i, "<=" si no fue informado.
+func signAt(signs []string, i int) string {
+	if i < len(signs) {
+		return signs[i]
+	}
+	return "<="
+}
+
+// lowerAt devuelve la cota inferior de la fila de rango i, 0 si no fue informada.
+func lowerAt(lower []float64, i int) float64 {
+	if i < len(lower) {
+		return lower[i]
+	}
+	return 0
+}
+
+// rangeRow decide cómo se escribe en forma estándar la fila lo <= a·x <= hi para
+// que la solución inicial (x = 0) sea válida. Devuelve el factor por el que se
+// multiplica la fila, el lado derecho resultante y si hace falta una artificial:
+//   - lo <= 0 <= hi: a·x + s = hi, con 0 <= s <= hi - lo y s básica.
+//   - lo > 0: a·x - e + a = lo, con 0 <= e <= hi - lo.
+//   - hi < 0: se multiplica por -1 y queda como el caso anterior con lado derecho -hi.
+func rangeRow(lo, hi float64) (scale, rhs float64, artificial bool) {
+	switch {
+	case hi < 0:
+		return -1, -hi, true
+	case lo > 0:
+		return 1, lo, true
+	default:
+		return 1, hi, false
+	}
+}
+
+// labels traduce índices base 1 a los nombres del catálogo.
+func labels(vars []Variable, flipped []bool, idx []int) []string {
+	out := make([]string, len(idx))
+	for i, v := range idx {
+		out[i] = label(vars, flipped, v)
+	}
+	return out
+}
+
+// label devuelve el nombre de la variable con índice base 1 idx, o "" si no existe.
+// Una variable reemplazada por su complemento (u - x) se marca con un apóstrofo.
+func label(vars []Variable, flipped []bool, idx int) string {
+	if idx < 1 || idx > len(vars) {
+		return ""
+	}
+	if idx <= len(flipped) && flipped[idx-1] {
+		return vars[idx-1].Name + "'"
+	}
+	return vars[idx-1].Name
+}
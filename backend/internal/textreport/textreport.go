@@ -0,0 +1,266 @@
+// Package textreport escribe las iteraciones del simplex como tablas de texto
+// alineadas (para la terminal) o como tablas Markdown (para tickets y chats), con
+// el pivote marcado. WriteStep sirve por separado para imprimir una sola tabla.
+package textreport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// Style elige la forma de las tablas.
+type Style int
+
+const (
+	// Text alinea las columnas con espacios y marca el pivote entre corchetes.
+	Text Style = iota
+	// Markdown usa tablas GFM y marca el pivote en negrita.
+	Markdown
+)
+
+// WriteText escribe el resultado y todas las iteraciones como texto plano.
+func WriteText(w io.Writer, _ models.SimplexRequest, res simplex.Result) error {
+	return write(w, res, Text)
+}
+
+// WriteMarkdown escribe el resultado y todas las iteraciones en Markdown.
+func WriteMarkdown(w io.Writer, _ models.SimplexRequest, res simplex.Result) error {
+	return write(w, res, Markdown)
+}
+
+func write(w io.Writer, res simplex.Result, style Style) error {
+	var b strings.Builder
+	title := func(level int, s string) {
+		if style == Markdown {
+			fmt.Fprintf(&b, "%s %s\n\n", strings.Repeat("#", level), s)
+			return
+		}
+		underline := "="
+		if level > 1 {
+			underline = "-"
+		}
+		fmt.Fprintf(&b, "%s\n%s\n\n", s, strings.Repeat(underline, utf8.RuneCountInString(s)))
+	}
+
+	// En Markdown cada dato va como ítem de lista para que no se junten en un párrafo
+	line := func(format string, a ...any) {
+		if style == Markdown {
+			b.WriteString("- ")
+		}
+		fmt.Fprintf(&b, format+"\n", a...)
+	}
+
+	title(1, "Resultado del Simplex")
+	line("Estado: %s", statusText(res.Status))
+	if res.Status == simplex.StatusOptimal {
+		line("Z* = %s", simplex.FormatNumber(res.OptimalValue))
+		values := res.Values()
+		for _, v := range res.Variables {
+			if v.Kind == simplex.Decision {
+				line("%s = %s", v.Name, simplex.FormatNumber(values[v.Name]))
+			}
+		}
+	}
+	if res.Warning != "" {
+		line("Aviso: %s", res.Warning)
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+
+	for _, st := range res.Steps {
+		b.Reset()
+		b.WriteString("\n")
+		title(2, fmt.Sprintf("Iteración %d", st.Iteration))
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+		if err := WriteStep(w, st, style); err != nil {
+			return err
+		}
+		if s := summary(st); s != "" {
+			if _, err := fmt.Fprintf(w, "\n%s\n", s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteStep escribe el tableau de una iteración: encabezado, fila cj, una fila por
+// restricción y las filas Zj y Cj − Zj. Los valores con gran M se muestran en
+// forma simbólica.
+func WriteStep(w io.Writer, st simplex.SimplexStep, style Style) error {
+	grid := cells(st, style)
+	if len(grid) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	if style == Markdown {
+		writeMarkdown(&b, grid)
+	} else {
+		writeText(&b, grid)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// cells arma la grilla de textos de la tabla; la primera fila es el encabezado.
+func cells(st simplex.SimplexStep, style Style) [][]string {
+	if len(st.Table) == 0 {
+		return nil
+	}
+	nVars := len(st.Table[0]) - 1
+	header := []string{"cb", "base"}
+	for j := range nVars {
+		label := fmt.Sprintf("v%d", j+1)
+		if j < len(st.ColumnLabels) {
+			label = st.ColumnLabels[j]
+		}
+		header = append(header, label)
+	}
+	grid := [][]string{append(header, "R")}
+
+	if cj := simplex.FormatValues(st.Cj, st.CjM); len(cj) == nVars {
+		grid = append(grid, append(append([]string{"", "cj"}, cj...), ""))
+	}
+	cb := simplex.FormatValues(st.Cb, st.CbM)
+	for i, r := range st.Table {
+		row := []string{"", ""}
+		if i < len(cb) {
+			row[0] = cb[i]
+		}
+		if i < len(st.BaseLabels) {
+			row[1] = st.BaseLabels[i]
+		}
+		for j := range nVars {
+			s := simplex.FormatNumber(r[j])
+			if i == st.PivotRow && j == st.PivotCol {
+				s = mark(s, style)
+			}
+			row = append(row, s)
+		}
+		grid = append(grid, append(row, simplex.FormatNumber(r[nVars])))
+	}
+	if zj := simplex.FormatValues(st.Zj, st.ZjM); len(zj) == nVars {
+		z := simplex.FormatNumber(st.ObjectiveValue)
+		if st.ObjectiveValueM != nil {
+			z = st.ObjectiveValueM.String()
+		}
+		grid = append(grid, append(append([]string{"", "zj"}, zj...), z))
+	}
+	if delta := simplex.FormatValues(st.CjMinusZj, st.CjMinusZjM); len(delta) == nVars {
+		grid = append(grid, append(append([]string{"", "cj−zj"}, delta...), ""))
+	}
+	return grid
+}
+
+func mark(s string, style Style) string {
+	if style == Markdown {
+		return "**" + s + "**"
+	}
+	return "[" + s + "]"
+}
+
+// writeText alinea las columnas a la derecha, salvo la base, y separa el
+// encabezado y las filas Zj con una línea.
+func writeText(b *strings.Builder, grid [][]string) {
+	widths := make([]int, len(grid[0]))
+	for _, row := range grid {
+		for j, s := range row {
+			widths[j] = max(widths[j], utf8.RuneCountInString(s))
+		}
+	}
+	rule := func() {
+		for j, wd := range widths {
+			if j > 0 {
+				b.WriteString("-+-")
+			}
+			b.WriteString(strings.Repeat("-", wd))
+		}
+		b.WriteString("\n")
+	}
+	for i, row := range grid {
+		if row[1] == "zj" {
+			rule()
+		}
+		var line strings.Builder
+		for j, s := range row {
+			if j > 0 {
+				line.WriteString(" | ")
+			}
+			pad := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(s))
+			if j == 1 {
+				line.WriteString(s + pad)
+			} else {
+				line.WriteString(pad + s)
+			}
+		}
+		// Sin espacios al final: las filas cj y cj−zj no tienen R
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+		if i == 0 {
+			rule()
+		}
+	}
+}
+
+// writeMarkdown escribe una tabla GFM con los números alineados a la derecha.
+func writeMarkdown(b *strings.Builder, grid [][]string) {
+	for i, row := range grid {
+		b.WriteString("|")
+		for _, s := range row {
+			b.WriteString(" " + strings.ReplaceAll(s, "|", `\|`) + " |")
+		}
+		b.WriteString("\n")
+		if i == 0 {
+			b.WriteString("|")
+			for j := range row {
+				if j == 1 {
+					b.WriteString(" :--- |")
+				} else {
+					b.WriteString(" ---: |")
+				}
+			}
+			b.WriteString("\n")
+		}
+	}
+}
+
+// summary describe el pivote de la iteración o, en la última, el veredicto.
+func summary(st simplex.SimplexStep) string {
+	if st.BoundFlip {
+		return fmt.Sprintf("Entra %s, que llega a su cota superior (t = %s): se reemplaza por su complemento y la base no cambia.", st.EnteringLabel, simplex.FormatNumber(st.TValue))
+	}
+	switch st.Status {
+	case simplex.StatusOptimal:
+		return fmt.Sprintf("Tabla óptima. Z = %s", simplex.FormatNumber(st.ObjectiveValue))
+	case simplex.StatusInfeasible:
+		return "Tabla final: quedan variables artificiales positivas en la base, el problema es infactible."
+	case simplex.StatusUnbounded:
+		return fmt.Sprintf("Entra %s, pero su columna no tiene elementos positivos: el problema no está acotado.", st.EnteringLabel)
+	case "":
+		return fmt.Sprintf("Entra %s, sale %s, t = %s.", st.EnteringLabel, st.LeavingLabel, simplex.FormatNumber(st.TValue))
+	}
+	return ""
+}
+
+func statusText(s simplex.Status) string {
+	switch s {
+	case simplex.StatusOptimal:
+		return "solución óptima"
+	case simplex.StatusInfeasible:
+		return "problema infactible"
+	case simplex.StatusUnbounded:
+		return "problema no acotado"
+	case simplex.StatusSingular:
+		return "base singular"
+	case simplex.StatusIterationLimit:
+		return "límite de iteraciones"
+	}
+	return "sin resultado"
+}
@@ -0,0 +1,68 @@
+package textreport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func solve() simplex.Result {
+	return simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, []float64{3, 2}),
+		Constraints: mat.NewDense(2, 3, []float64{1, 1, 4, 1, 3, 6}),
+		VarNames:    []string{"mesas", "sillas"},
+	})
+}
+
+func TestWriteText(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteText(&buf, models.SimplexRequest{}, solve()))
+	out := buf.String()
+
+	assert.Contains(t, out, "Estado: solución óptima")
+	assert.Contains(t, out, "Z* = 12")
+	assert.Contains(t, out, "mesas = 4")
+	assert.Contains(t, out, "Iteración 0\n-----------")
+	assert.Contains(t, out, "Entra mesas, sale s1, t = 4.")
+	// El pivote de la primera tabla queda entre corchetes
+	assert.Contains(t, out, "[1]")
+
+	// Las columnas de una tabla quedan alineadas
+	var bars []int
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, " | ") {
+			bars = append(bars, len([]rune(line[:strings.LastIndex(line, "|")])))
+		} else if len(bars) > 0 && !strings.Contains(line, "-+-") {
+			break
+		}
+	}
+	assert.Len(t, bars, 6)
+	for _, b := range bars {
+		assert.Equal(t, bars[0], b)
+	}
+	assert.NotContains(t, out, " \n")
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteMarkdown(&buf, models.SimplexRequest{}, solve()))
+	out := buf.String()
+
+	assert.Contains(t, out, "# Resultado del Simplex\n")
+	assert.Contains(t, out, "- Z* = 12\n")
+	assert.Contains(t, out, "## Iteración 1\n")
+	assert.Contains(t, out, "| cb | base | mesas | sillas | s1 | s2 | R |\n| ---: | :--- |")
+	assert.Contains(t, out, "**1**")
+}
+
+func TestWriteStep_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, WriteStep(&buf, simplex.SimplexStep{}, Text))
+	assert.Empty(t, buf.String())
+}
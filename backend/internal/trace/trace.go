@@ -0,0 +1,125 @@
+// Package trace exporta los datos de cada iteración en formatos pensados para
+// análisis: un zip con un CSV por tabla más un resumen, o JSON Lines (un paso por
+// línea). Los números se escriben con toda su precisión.
+package trace
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+)
+
+// WriteCSV escribe en w un zip con resumen.csv (variable entrante y saliente, t y
+// objetivo por iteración) y un iteracion_NN.csv con el tableau de cada paso.
+func WriteCSV(w io.Writer, _ models.SimplexRequest, res simplex.Result) error {
+	zw := zip.NewWriter(w)
+
+	f, err := zw.Create("resumen.csv")
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(f)
+	_ = cw.Write([]string{"iteration", "entering", "leaving", "t", "objective", "bound_flip", "status"})
+	for _, st := range res.Steps {
+		entering, leaving, t := st.EnteringLabel, st.LeavingLabel, number(st.TValue)
+		if st.Status != "" && st.Status != simplex.StatusUnbounded {
+			// La tabla final no pivotea
+			entering, leaving, t = "", "", ""
+		}
+		if st.BoundFlip || st.Status == simplex.StatusUnbounded {
+			leaving = ""
+		}
+		_ = cw.Write([]string{
+			strconv.Itoa(st.Iteration), entering, leaving, t,
+			number(st.ObjectiveValue), strconv.FormatBool(st.BoundFlip), string(st.Status),
+		})
+	}
+	if err := flush(cw); err != nil {
+		return err
+	}
+
+	for _, st := range res.Steps {
+		f, err := zw.Create(fmt.Sprintf("iteracion_%02d.csv", st.Iteration))
+		if err != nil {
+			return err
+		}
+		if err := writeTableau(csv.NewWriter(f), st); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeTableau escribe una tabla: encabezado, fila cj, una fila por restricción y
+// las filas Zj y Cj - Zj. La columna R de la fila Zj lleva el valor Z.
+func writeTableau(cw *csv.Writer, st simplex.SimplexStep) error {
+	if len(st.Table) == 0 {
+		return flush(cw)
+	}
+	nVars := len(st.Table[0]) - 1
+	header := []string{"cb", "base"}
+	for j := range nVars {
+		label := fmt.Sprintf("v%d", j+1)
+		if j < len(st.ColumnLabels) {
+			label = st.ColumnLabels[j]
+		}
+		header = append(header, label)
+	}
+	_ = cw.Write(append(header, "R"))
+
+	if len(st.Cj) == nVars {
+		_ = cw.Write(append(append([]string{"", "cj"}, numbers(st.Cj)...), ""))
+	}
+	for i, row := range st.Table {
+		rec := []string{"", ""}
+		if i < len(st.Cb) {
+			rec[0] = number(st.Cb[i])
+		}
+		if i < len(st.BaseLabels) {
+			rec[1] = st.BaseLabels[i]
+		}
+		_ = cw.Write(append(rec, numbers(row)...))
+	}
+	if len(st.Zj) == nVars {
+		_ = cw.Write(append(append([]string{"", "zj"}, numbers(st.Zj)...), number(st.ObjectiveValue)))
+	}
+	if len(st.CjMinusZj) == nVars {
+		_ = cw.Write(append(append([]string{"", "cj-zj"}, numbers(st.CjMinusZj)...), ""))
+	}
+	return flush(cw)
+}
+
+// WriteNDJSON escribe un objeto JSON por línea, uno por iteración, con los mismos
+// campos que los pasos de la respuesta de /process.
+func WriteNDJSON(w io.Writer, _ models.SimplexRequest, res simplex.Result) error {
+	enc := json.NewEncoder(w)
+	for _, st := range res.Steps {
+		if err := enc.Encode(st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func flush(cw *csv.Writer) error {
+	cw.Flush()
+	return cw.Error()
+}
+
+func numbers(v []float64) []string {
+	out := make([]string, len(v))
+	for i, x := range v {
+		out[i] = number(x)
+	}
+	return out
+}
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
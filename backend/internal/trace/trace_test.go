@@ -0,0 +1,71 @@
+package trace
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"autosimplex/internal/models"
+	"autosimplex/internal/simplex"
+
+	"github.com/stretchr/testify/assert"
+	"gonum.org/v1/gonum/mat"
+)
+
+func solve() simplex.Result {
+	return simplex.SolveProblem(simplex.Problem{
+		Objective:   mat.NewVecDense(2, []float64{3, 2}),
+		Constraints: mat.NewDense(2, 3, []float64{1, 1, 4, 1, 3, 6}),
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	res := solve()
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCSV(&buf, models.SimplexRequest{}, res))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	files := map[string][][]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		assert.NoError(t, err)
+		records, err := csv.NewReader(rc).ReadAll()
+		assert.NoError(t, err)
+		files[f.Name] = records
+		_ = rc.Close()
+	}
+	assert.Len(t, files, len(res.Steps)+1)
+
+	summary := files["resumen.csv"]
+	assert.Equal(t, []string{"iteration", "entering", "leaving", "t", "objective", "bound_flip", "status"}, summary[0])
+	assert.Equal(t, []string{"0", "x1", "s1", "4", "0", "false", ""}, summary[1])
+	assert.Equal(t, []string{"1", "", "", "", "12", "false", "optimal"}, summary[2])
+
+	first := files["iteracion_00.csv"]
+	assert.Equal(t, []string{"cb", "base", "x1", "x2", "s1", "s2", "R"}, first[0])
+	assert.Equal(t, []string{"", "cj", "3", "2", "0", "0", ""}, first[1])
+	assert.Equal(t, []string{"0", "s1", "1", "1", "1", "0", "4"}, first[2])
+	assert.Equal(t, []string{"", "zj", "0", "0", "0", "0", "0"}, first[4])
+	assert.Equal(t, []string{"", "cj-zj", "3", "2", "0", "0", ""}, first[5])
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	res := solve()
+	var buf bytes.Buffer
+	assert.NoError(t, WriteNDJSON(&buf, models.SimplexRequest{}, res))
+
+	sc := bufio.NewScanner(&buf)
+	var steps []simplex.SimplexStep
+	for sc.Scan() {
+		var st simplex.SimplexStep
+		assert.NoError(t, json.Unmarshal(sc.Bytes(), &st))
+		steps = append(steps, st)
+	}
+	assert.Len(t, steps, len(res.Steps))
+	assert.Equal(t, "x1", steps[0].EnteringLabel)
+	assert.Equal(t, simplex.StatusOptimal, steps[len(steps)-1].Status)
+}
@@ -15,6 +15,7 @@ func main() {
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Crosscheck")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -25,6 +26,12 @@ func main() {
 	})
 
 	r.POST("/process", handler.Process())
+	r.POST("/export", handler.Export())
+	r.POST("/graphical", handler.Graphical())
+	r.POST("/bases", handler.Bases())
+	r.POST("/standard-form", handler.StandardForm())
+	r.GET("/schema", handler.Schemas())
+	r.GET("/schema/:version/:name", handler.Schema())
 
 	if err := r.Run(":8080"); err != nil {
 		panic("Error al iniciar el servidor: " + err.Error())
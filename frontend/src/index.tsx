@@ -357,13 +357,15 @@ export function App() {
                                     ))}
                                 </tbody>
                             </table>
-                            {/* Mensaje del Pivote */}
+                            {/* Mensaje del Pivote: la tabla final (con status) no pivotea */}
+                            {!step.status && step.pivot_row >= 0 && (
                             <div class="pivot-message">
                                 Ingresa la variable <strong>{step.entering_var <= n ? `X${step.entering_var}` : `S${step.entering_var - n}`}</strong> y sale de la base la variable <strong>{step.leaving_var <= n ? `X${step.leaving_var}` : `S${step.leaving_var - n}`}</strong>. El elemento pivote es <strong>{(() => {
                                     const pv = (step.table && step.table[step.pivot_row]) ? step.table[step.pivot_row][step.pivot_col] : null;
                                     return pv != null ? pv.toFixed(2) : step.t_value?.toFixed?.(2) || '';
                                 })()}</strong>
                             </div>
+                            )}
                         </div>
                     );
                 })}